@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"periodic-table/src/elements"
+	"periodic-table/src/formula"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+var balanceFormats = []string{"text", "json"}
+
+type balanceOptions struct {
+	format string
+}
+
+func balanceFlags() (*flag.FlagSet, *balanceOptions) {
+	var o balanceOptions
+	fs := flag.NewFlagSet("balance", flag.ContinueOnError)
+	fs.StringVar(&o.format, "format", "text", "output format: "+strings.Join(balanceFormats, ", "))
+	fs.Usage = commandUsage(fs, "balance")
+	return fs, &o
+}
+
+// runBalance prints an equation with the smallest whole coefficients which
+// balance it. It exits with 2 if the equation can't be read and with 1 if it
+// can't be balanced.
+func runBalance(args []string) int {
+	fs, o := balanceFlags()
+	words, err := parseArgs(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(words) == 0 {
+		fs.Usage()
+		return 2
+	}
+	if !slices.Contains(balanceFormats, o.format) {
+		fmt.Fprintf(os.Stderr, "%s balance: unknown format %q, expected one of %s\n", program, o.format, strings.Join(balanceFormats, ", "))
+		return 2
+	}
+
+	e, err := formula.ParseEquation(strings.Join(words, " "), elements.ReadData())
+	if err != nil {
+		printFormulaError(err)
+		return 2
+	}
+	balanced, err := e.Balance()
+	if err != nil {
+		printFormulaError(err)
+		return 1
+	}
+
+	if o.format == "json" {
+		err = writeBalanceJSON(os.Stdout, balanced)
+	} else {
+		_, err = fmt.Fprintln(os.Stdout, balanced)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s balance: %s\n", program, err)
+		return 1
+	}
+	return 0
+}
+
+type balanceSpecies struct {
+	Formula     string `json:"formula"`
+	Coefficient int    `json:"coefficient"`
+}
+
+type balanceResult struct {
+	Equation  string           `json:"equation"`
+	Balanced  string           `json:"balanced"`
+	Reactants []balanceSpecies `json:"reactants"`
+	Products  []balanceSpecies `json:"products"`
+}
+
+func writeBalanceJSON(w io.Writer, e formula.Equation) error {
+	species := func(side []formula.Species) []balanceSpecies {
+		var out []balanceSpecies
+		for _, s := range side {
+			out = append(out, balanceSpecies{Formula: s.Written(), Coefficient: s.Coefficient})
+		}
+		return out
+	}
+	r := balanceResult{
+		Equation:  e.Source,
+		Balanced:  e.String(),
+		Reactants: species(e.Reactants),
+		Products:  species(e.Products),
+	}
+	// Arrows are left as typed rather than escaped for HTML.
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func completeBalance(args []string, word string) []string {
+	fs, _ := balanceFlags()
+	candidates, _ := completeFlag(fs, map[string]func() []string{"format": constant(balanceFormats...)}, args, word)
+	return candidates
+}
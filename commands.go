@@ -0,0 +1,210 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// program is the name commands are run by, used in usage and completion
+// scripts.
+const program = "periodic-table"
+
+// command is a subcommand, run as "periodic-table <name> [args]" instead of
+// the table. run returns the exit code: 0 on success, 1 when the command ran
+// but failed, such as for an unknown element, and 2 for usage errors.
+// complete returns the candidates for the word being typed, given the
+// arguments before it.
+type command struct {
+	name     string
+	args     string
+	summary  string
+	run      func(args []string) int
+	complete func(args []string, word string) []string
+}
+
+// commands are the subcommands in the order they are listed in usage. It is
+// filled in init as the help and completion commands refer to it.
+var commands []command
+
+func init() {
+	commands = []command{
+		{
+			name:     "info",
+			args:     "[-format table|json|yaml|csv] [-fields list] [-template text] <name|symbol|number>...",
+			summary:  "print the data of elements",
+			run:      runInfo,
+			complete: completeInfo,
+		},
+		{
+			name:     "query",
+			args:     "[-format table|csv|json|ndjson] [-fields list] [-sort field] [-desc] [-limit n] [expression]",
+			summary:  "print the elements matching a filter expression, all without one",
+			run:      runQuery,
+			complete: completeQuery,
+		},
+		{
+			name:     "print",
+			args:     "[-width n] [-color auto|always|never] [-highlight list] [-scheme id] [-heatmap property] [-scale linear|log]",
+			summary:  "print the table once, fit to the width of the terminal",
+			run:      runPrint,
+			complete: completePrint,
+		},
+		{
+			name:     "export",
+			args:     "[-format html|svg|png] [-o file] [-background auto|light|dark] [-highlight list] [-scheme id] [-heatmap property] [-scale linear|log]",
+			summary:  "write the table with its legend as an HTML page, an SVG or a PNG image",
+			run:      runExport,
+			complete: completeExport,
+		},
+		{
+			name:     "mass",
+			args:     "[-format table|json] <formula>",
+			summary:  "print the molar mass and percent composition of a formula",
+			run:      runMass,
+			complete: completeMass,
+		},
+		{
+			name:     "balance",
+			args:     "[-format text|json] <equation>",
+			summary:  "balance a chemical equation with the smallest whole coefficients",
+			run:      runBalance,
+			complete: completeBalance,
+		},
+		{
+			name:     "stoich",
+			args:     "[-format table|json] <equation> <species=amount>...",
+			summary:  "find the limiting reagent and the yields of a reaction for amounts in g, mol or L of gas at STP",
+			run:      runStoich,
+			complete: completeStoich,
+		},
+		{
+			name:     "vsepr",
+			args:     "[-format text|json] <formula>",
+			summary:  "predict the shape of a molecule or ion around its central atom by VSEPR",
+			run:      runVSEPR,
+			complete: completeVSEPR,
+		},
+		{
+			name:    "config",
+			args:    "<print-defaults|schema|path|check>",
+			summary: "print or check the config file",
+			run:     func(args []string) int { return runConfig(*configPath, args) },
+			complete: func(args []string, word string) []string {
+				if len(args) > 0 {
+					return nil
+				}
+				return matching([]string{"print-defaults", "schema", "path", "check"}, word)
+			},
+		},
+		{
+			name:     "completion",
+			args:     "<bash|zsh|fish>",
+			summary:  "print a shell completion script",
+			run:      runCompletion,
+			complete: completeCompletion,
+		},
+		{
+			name:    "help",
+			summary: "print this help",
+			run: func([]string) int {
+				flag.CommandLine.SetOutput(os.Stdout)
+				usage()
+				return 0
+			},
+		},
+	}
+}
+
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// runCommand runs the named command and returns its exit code.
+func runCommand(name string, args []string) int {
+	if name == completeCommand {
+		return runComplete(args)
+	}
+
+	c, ok := findCommand(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "%s: unknown command %q\n", program, name)
+		usage()
+		return 2
+	}
+	return c.run(args)
+}
+
+// usage prints the flags and commands of the program.
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "usage: %s [flags] [command [args]]\n\n", program)
+	fmt.Fprintln(out, "Without a command the table is opened.")
+
+	fmt.Fprintln(out, "\ncommands:")
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %s\t%s\n", c.name, c.summary)
+	}
+	w.Flush()
+
+	fmt.Fprintln(out, "\nflags:")
+	flag.PrintDefaults()
+	fmt.Fprintf(out, "\nRun \"%s <command> -help\" for the arguments of a command.\n", program)
+}
+
+// commandUsage returns the usage function of a command's flag set.
+func commandUsage(fs *flag.FlagSet, c string) func() {
+	return func() {
+		cmd, _ := findCommand(c)
+		fmt.Fprintf(fs.Output(), "usage: %s %s %s\n\n%s.\n", program, cmd.name, cmd.args, strings.ToUpper(cmd.summary[:1])+cmd.summary[1:])
+		if hasFlags(fs) {
+			fmt.Fprintln(fs.Output(), "\nflags:")
+			fs.PrintDefaults()
+		}
+	}
+}
+
+func hasFlags(fs *flag.FlagSet) bool {
+	var found bool
+	fs.VisitAll(func(*flag.Flag) { found = true })
+	return found
+}
+
+// parseArgs parses the flags of fs wherever they appear among args, as in
+// "info Fe -format json", and returns the other arguments. Arguments after
+// "--" are never flags.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if parsed := len(args) - len(rest); parsed > 0 && args[parsed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// flagExitCode returns the exit code for an error parsing flags, which have
+// already been reported: 0 if help was asked for and 2 otherwise.
+func flagExitCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	return 2
+}
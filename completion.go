@@ -0,0 +1,181 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+)
+
+// completeCommand is the hidden command the completion scripts run to find
+// candidates: "periodic-table __complete <args>... <word>", where word is the
+// word being typed.
+const completeCommand = "__complete"
+
+var completionScripts = map[string]string{
+	"bash": `# bash completion for periodic-table, load with
+#   source <(periodic-table completion bash)
+_periodic_table() {
+	local IFS=$'\n'
+	COMPREPLY=($(periodic-table __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _periodic_table periodic-table
+`,
+	"zsh": `#compdef periodic-table
+# zsh completion for periodic-table, load with
+#   source <(periodic-table completion zsh)
+_periodic_table() {
+	local -a candidates
+	candidates=("${(@f)$(periodic-table __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+	compadd -a candidates
+}
+compdef _periodic_table periodic-table
+`,
+	"fish": `# fish completion for periodic-table, load with
+#   periodic-table completion fish | source
+complete -c periodic-table -f -a '(periodic-table __complete (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null)'
+`,
+}
+
+func runCompletion(args []string) int {
+	if len(args) != 1 || completionScripts[args[0]] == "" {
+		fmt.Fprintf(os.Stderr, "usage: %s completion <bash|zsh|fish>\n", program)
+		return 2
+	}
+	fmt.Print(completionScripts[args[0]])
+	return 0
+}
+
+func completeCompletion(args []string, word string) []string {
+	if len(args) > 0 {
+		return nil
+	}
+	return matching([]string{"bash", "fish", "zsh"}, word)
+}
+
+// runComplete prints the candidates for the last of args, one per line.
+func runComplete(args []string) int {
+	if len(args) == 0 {
+		return 0
+	}
+	for _, candidate := range complete(args[:len(args)-1], args[len(args)-1]) {
+		fmt.Println(candidate)
+	}
+	return 0
+}
+
+// complete returns the candidates for word given the arguments before it,
+// which start with the program's flags followed by a command.
+func complete(args []string, word string) []string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			c, ok := findCommand(arg)
+			if !ok || c.complete == nil {
+				return nil
+			}
+			return c.complete(args[i+1:], word)
+		}
+		if f := lookupFlag(flag.CommandLine, arg); f != nil && !isBoolFlag(f) && !strings.Contains(arg, "=") {
+			i++
+		}
+	}
+
+	if strings.HasPrefix(word, "-") {
+		return matching(flagNames(flag.CommandLine), word)
+	}
+	var names []string
+	for _, c := range commands {
+		names = append(names, c.name)
+	}
+	return matching(names, word)
+}
+
+// completeFlag completes the flags of fs and the values of the flags listed
+// in values. It returns false if word is neither, leaving it to the command.
+func completeFlag(fs *flag.FlagSet, values map[string]func() []string, args []string, word string) ([]string, bool) {
+	// bash splits "-format=j" into "-format", "=" and "j".
+	if len(args) > 1 && args[len(args)-1] == "=" {
+		args = args[:len(args)-1]
+	}
+	if len(args) > 0 {
+		if f := lookupFlag(fs, args[len(args)-1]); f != nil && !isBoolFlag(f) && !strings.Contains(args[len(args)-1], "=") {
+			if candidates, ok := values[f.Name]; ok {
+				return matchingList(candidates(), word), true
+			}
+			return nil, true
+		}
+	}
+	if i := strings.Index(word, "="); strings.HasPrefix(word, "-") && i >= 0 {
+		var matches []string
+		if f := lookupFlag(fs, word); f != nil && values[f.Name] != nil {
+			for _, m := range matchingList(values[f.Name](), word[i+1:]) {
+				matches = append(matches, word[:i+1]+m)
+			}
+		}
+		return matches, true
+	}
+	if strings.HasPrefix(word, "-") {
+		return matching(flagNames(fs), word), true
+	}
+	return nil, false
+}
+
+// matching returns the candidates which start with word, ignoring case. They
+// are spelled with the case of word, so "ir" completes to "iron", as shells
+// drop candidates which don't start with the word as typed.
+func matching(candidates []string, word string) []string {
+	var matches []string
+	for _, c := range candidates {
+		if len(c) >= len(word) && strings.EqualFold(c[:len(word)], word) {
+			matches = append(matches, word+c[len(word):])
+		}
+	}
+	return matches
+}
+
+// matchingList completes the last item of a comma separated list.
+func matchingList(candidates []string, word string) []string {
+	i := strings.LastIndex(word, ",")
+	prefix := word[:i+1]
+
+	var matches []string
+	for _, m := range matching(candidates, word[i+1:]) {
+		matches = append(matches, prefix+m)
+	}
+	return matches
+}
+
+func lookupFlag(fs *flag.FlagSet, arg string) *flag.Flag {
+	name := strings.TrimLeft(arg, "-")
+	if i := strings.Index(name, "="); i >= 0 {
+		name = name[:i]
+	}
+	return fs.Lookup(name)
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+func flagNames(fs *flag.FlagSet) []string {
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, "-"+f.Name)
+	})
+	return names
+}
+
+func constant(values ...string) func() []string {
+	return func() []string { return values }
+}
+
+func fieldNames() []string {
+	var names []string
+	for _, f := range element.Fields {
+		names = append(names, f.Name)
+	}
+	return names
+}
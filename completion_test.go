@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		args []string
+		word string
+		want []string
+	}{
+		{nil, "co", []string{"config", "completion"}},
+		{[]string{"-config", "x.yaml"}, "i", []string{"info"}},
+		{nil, "-acc", []string{"-accessible"}},
+		{[]string{"config"}, "p", []string{"print-defaults", "path"}},
+		{[]string{"info"}, "-fo", []string{"-format"}},
+		{[]string{"info", "-format"}, "y", []string{"yaml"}},
+		{[]string{"info", "-format", "="}, "c", []string{"csv"}},
+		{[]string{"info"}, "-format=j", []string{"-format=json"}},
+		{[]string{"info", "-fields"}, "symbol,atomic_m", []string{"symbol,atomic_mass"}},
+		{[]string{"info", "-template"}, "x", nil},
+		{[]string{"query", "phase = gas", "-sort"}, "boiling", []string{"boiling_point"}},
+		{[]string{"query"}, "-d", []string{"-desc"}},
+		{[]string{"print", "-color"}, "n", []string{"never"}},
+		{[]string{"export", "-format"}, "s", []string{"svg"}},
+		{[]string{"export"}, "-b", []string{"-background"}},
+		{[]string{"mass"}, "-f", []string{"-format"}},
+		{[]string{"balance", "-format"}, "j", []string{"json"}},
+		{[]string{"vsepr", "-format"}, "t", []string{"text"}},
+		{[]string{"stoich"}, "-", []string{"-format"}},
+		{[]string{"print", "-highlight"}, "Fe,Z", []string{"Fe,Zn", "Fe,Zr"}},
+		{[]string{"nope"}, "x", nil},
+	}
+	for _, tt := range tests {
+		if got := complete(tt.args, tt.word); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("complete(%q, %q) = %q, want %q", tt.args, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestMatching(t *testing.T) {
+	got := matching([]string{"Iron", "Iridium", "Ir", "Fe"}, "ir")
+	want := []string{"iron", "iridium", "ir"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("matching() = %q, want %q", got, want)
+	}
+}
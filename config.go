@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"periodic-table/src/config"
+)
+
+const configUsage = `usage: periodic-table [-config file] config <command>
+
+commands:
+  print-defaults  print a config file holding every setting at its default
+  schema          print a JSON schema of the config file
+  path            print the path of the config file
+  check           check the config file for errors`
+
+// loadConfig reads the settings from path, or from the default path if path
+// is empty. It returns the path the settings were read from. A missing
+// default file leaves the default settings in place.
+func loadConfig(path string) (config.Config, string, error) {
+	if path != "" {
+		c, err := config.Load(path)
+		return c, path, err
+	}
+
+	path, err := config.DefaultPath()
+	if err != nil {
+		return config.Default(), "", nil
+	}
+	c, err := config.Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config.Default(), path, nil
+	}
+	return c, path, err
+}
+
+// printConfigError prints err, with the offending line if it points into the
+// config file.
+func printConfigError(err error) {
+	var configErr *config.Error
+	if errors.As(err, &configErr) && configErr.Caret() != "" {
+		fmt.Fprintln(os.Stderr, configErr.Caret())
+	}
+	fmt.Fprintln(os.Stderr, err)
+}
+
+// runConfig runs a config command and returns the exit code: 0 on success, 1
+// if the config file is not valid and 2 for usage errors.
+func runConfig(path string, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, configUsage)
+		return 2
+	}
+
+	var (
+		out []byte
+		err error
+	)
+	switch args[0] {
+	case "print-defaults":
+		out, err = config.PrintDefaults()
+	case "schema":
+		out, err = config.JSONSchema()
+		out = append(out, '\n')
+	case "path":
+		_, path, _ = loadConfig(path)
+		out = []byte(path + "\n")
+	case "check":
+		if _, path, err = loadConfig(path); err != nil {
+			printConfigError(err)
+			return 1
+		}
+		out = []byte(path + ": ok\n")
+	default:
+		fmt.Fprintln(os.Stderr, configUsage)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	os.Stdout.Write(out)
+	return 0
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"periodic-table/src/elements"
+	"periodic-table/src/export"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/theme"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/exp/slices"
+)
+
+var exportBackgrounds = []string{"auto", "light", "dark"}
+
+type exportOptions struct {
+	tableOptions
+	format     string
+	output     string
+	background string
+}
+
+func exportFlags() (*flag.FlagSet, *exportOptions) {
+	var o exportOptions
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.StringVar(&o.format, "format", "", "output format: "+strings.Join(export.Formats, ", ")+", by default the extension of the output file")
+	fs.StringVar(&o.output, "o", "", "file to write, stdout if empty")
+	fs.StringVar(&o.background, "background", "auto", "page background, auto to follow the terminal: "+strings.Join(exportBackgrounds, ", "))
+	o.tableOptions.register(fs)
+	fs.Usage = commandUsage(fs, "export")
+	return fs, &o
+}
+
+// runExport writes the table, colored as in the terminal, to a file for
+// printing or the web.
+func runExport(args []string) int {
+	fs, o := exportFlags()
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(rest) > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	c, _, err := loadConfig(*configPath)
+	if err != nil {
+		printConfigError(err)
+		return 1
+	}
+
+	format := o.format
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(o.output)), ".")
+		if format == "htm" {
+			format = "html"
+		}
+		if format == "" {
+			return exportUsageError(fmt.Errorf("give a -format or an output file ending in .html, .svg or .png"))
+		}
+	}
+	if !slices.Contains(export.Formats, format) {
+		return exportUsageError(fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(export.Formats, ", ")))
+	}
+	if !slices.Contains(exportBackgrounds, o.background) {
+		return exportUsageError(fmt.Errorf("unknown background %q, expected one of %s", o.background, strings.Join(exportBackgrounds, ", ")))
+	}
+	applyUnits(c)
+	s, err := o.activeScheme(c)
+	if err != nil {
+		return exportUsageError(err)
+	}
+	highlighted, ok := o.highlighted("export")
+	if !ok {
+		return 1
+	}
+
+	theme.Set(c.ResolvedTheme())
+	dark := o.background == "dark"
+	if o.background == "auto" {
+		dark = lipgloss.HasDarkBackground()
+	}
+	table := export.New(s, elements.ReadElements(), tableColumns, func(d element.Data) bool {
+		return isAny(d, highlighted)
+	}, dark)
+
+	if err := writeExport(o.output, func(w io.Writer) error { return table.Write(w, format) }); err != nil {
+		fmt.Fprintf(os.Stderr, "%s export: %s\n", program, err)
+		return 1
+	}
+	return 0
+}
+
+// writeExport runs write on the file at path, or on stdout if path is empty.
+func writeExport(path string, write func(w io.Writer) error) error {
+	if path == "" {
+		b := bufio.NewWriter(os.Stdout)
+		if err := write(b); err != nil {
+			return err
+		}
+		return b.Flush()
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	b := bufio.NewWriter(f)
+	if err := write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := b.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func exportUsageError(err error) int {
+	fmt.Fprintf(os.Stderr, "%s export: %s\n", program, err)
+	return 2
+}
+
+func completeExport(args []string, word string) []string {
+	fs, _ := exportFlags()
+	values := map[string]func() []string{
+		"format":     constant(export.Formats...),
+		"background": constant(exportBackgrounds...),
+	}
+	for name, candidates := range tableValues {
+		values[name] = candidates
+	}
+	candidates, _ := completeFlag(fs, values, args, word)
+	return candidates
+}
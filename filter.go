@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"periodic-table/src/elements"
+	"periodic-table/src/query"
+	"text/tabwriter"
+)
+
+// printFilter prints the elements matching source and returns the exit code:
+// 0 if any element matched, 1 if none did and 2 if source is not a valid
+// filter.
+func printFilter(source string) int {
+	q, err := query.Parse(source)
+	if err != nil {
+		printQueryError(err)
+		return 2
+	}
+
+	matches := q.Filter(elements.ReadData())
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, d := range matches {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", d.AtomicNumber, d.Symbol, d.Element)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	if len(matches) == 0 {
+		return 1
+	}
+	return 0
+}
+
+// printQueryError prints err, pointing at the offending part of the query if
+// it is a parse error.
+func printQueryError(err error) {
+	var queryErr *query.Error
+	if errors.As(err, &queryErr) {
+		fmt.Fprintln(os.Stderr, queryErr.Caret())
+	}
+	fmt.Fprintln(os.Stderr, err)
+}
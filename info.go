@@ -0,0 +1,183 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"periodic-table/src/elements"
+	"periodic-table/src/output"
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"golang.org/x/exp/slices"
+)
+
+var infoFormats = []string{"table", "json", "yaml", "csv"}
+
+type infoOptions struct {
+	format   string
+	fields   string
+	template string
+}
+
+func infoFlags() (*flag.FlagSet, *infoOptions) {
+	var o infoOptions
+	fs := flag.NewFlagSet("info", flag.ContinueOnError)
+	fs.StringVar(&o.format, "format", "table", "output format: "+strings.Join(infoFormats, ", "))
+	fs.StringVar(&o.fields, "fields", "", "comma separated fields to print, all by default")
+	fs.StringVar(&o.template, "template", "", "Go template executed for each element, such as '{{.symbol}} {{.atomic_mass}}'")
+	fs.Usage = commandUsage(fs, "info")
+	return fs, &o
+}
+
+// runInfo prints the data of the elements named in args. It exits with 1 if
+// any of them is unknown, after printing the others.
+func runInfo(args []string) int {
+	fs, o := infoFlags()
+	names, err := parseArgs(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(names) == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	fields, err := parseFields(o.fields)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s info: %s\n", program, err)
+		return 2
+	}
+	var tmpl *template.Template
+	if o.template != "" {
+		if tmpl, err = template.New("info").Option("missingkey=error").Parse(o.template); err != nil {
+			fmt.Fprintf(os.Stderr, "%s info: %s\n", program, err)
+			return 2
+		}
+	} else if !slices.Contains(infoFormats, o.format) {
+		fmt.Fprintf(os.Stderr, "%s info: unknown format %q, expected one of %s\n", program, o.format, strings.Join(infoFormats, ", "))
+		return 2
+	}
+
+	all := elements.ReadData()
+	var (
+		found   []element.Data
+		unknown bool
+	)
+	for _, name := range names {
+		d, ok := element.Find(all, name)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "%s info: unknown element %q\n", program, name)
+			unknown = true
+			continue
+		}
+		found = append(found, d)
+	}
+
+	records := output.Records(fields, found)
+	switch {
+	case tmpl != nil:
+		err = writeTemplate(os.Stdout, tmpl, records)
+	case o.format == "json":
+		err = output.WriteJSON(os.Stdout, records)
+	case o.format == "yaml":
+		err = output.WriteYAML(os.Stdout, records)
+	case o.format == "csv":
+		err = output.WriteCSV(os.Stdout, fields, records)
+	default:
+		err = writeInfoTable(os.Stdout, fields, found)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s info: %s\n", program, err)
+		return 2
+	}
+
+	if unknown {
+		return 1
+	}
+	return 0
+}
+
+// parseFields returns the fields named in a comma separated list, or every
+// field if the list is empty.
+func parseFields(list string) ([]element.Field, error) {
+	if list == "" {
+		return element.Fields, nil
+	}
+
+	var fields []element.Field
+	for _, name := range strings.Split(list, ",") {
+		f, ok := element.FieldByName(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// writeTemplate executes tmpl for every record, ending each with a newline
+// unless the template does.
+func writeTemplate(w io.Writer, tmpl *template.Template, records []output.Record) error {
+	for _, r := range records {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, r.Map()); err != nil {
+			return err
+		}
+		text := b.String()
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		if _, err := io.WriteString(w, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeInfoTable writes a row for every field and a column for every
+// element, so a single element reads as a list of its properties.
+func writeInfoTable(w io.Writer, fields []element.Field, data []element.Data) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, f := range fields {
+		row := []string{f.Label}
+		for _, d := range data {
+			value := f.Text(d)
+			switch {
+			case f.Kind == element.Flag:
+				value = "no"
+				if f.IsSet(d) {
+					value = "yes"
+				}
+			case value == "":
+				value = "—"
+			case f.DisplayUnit() != "":
+				value += " " + f.DisplayUnit()
+			}
+			row = append(row, value)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func completeInfo(args []string, word string) []string {
+	fs, _ := infoFlags()
+	values := map[string]func() []string{"format": constant(infoFormats...), "fields": fieldNames}
+	if candidates, ok := completeFlag(fs, values, args, word); ok {
+		return candidates
+	}
+
+	var names []string
+	for _, d := range elements.ReadData() {
+		names = append(names, d.Element, d.Symbol)
+	}
+	return matching(names, word)
+}
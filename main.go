@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"periodic-table/ui"
@@ -8,14 +9,47 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+var (
+	filter     = flag.String("filter", "", "print the elements matching a filter expression and exit")
+	configPath = flag.String("config", "", "read settings from this file instead of the user config directory")
+	accessible = flag.Bool("accessible", false, "describe the table line by line for screen readers instead of drawing it")
+)
+
 func main() {
-	model, err := ui.CreateModel()
+	flag.Usage = usage
+	flag.Parse()
+
+	if *filter != "" {
+		os.Exit(printFilter(*filter))
+	}
+
+	if flag.NArg() > 0 {
+		os.Exit(runCommand(flag.Arg(0), flag.Args()[1:]))
+	}
+
+	c, path, err := loadConfig(*configPath)
+	if err != nil {
+		printConfigError(err)
+		os.Exit(1)
+	}
+
+	if *accessible {
+		c.View = "accessible"
+	}
+
+	model, err := ui.CreateModel(c, path)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	if err := tea.NewProgram(model, tea.WithAltScreen()).Start(); err != nil {
+	// The accessible view prints lines which must stay readable after they
+	// scroll by, so it doesn't take over the screen.
+	var options []tea.ProgramOption
+	if c.View != "accessible" {
+		options = append(options, tea.WithAltScreen())
+	}
+	if err := tea.NewProgram(model, options...).Start(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
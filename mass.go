@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"periodic-table/src/elements"
+	"periodic-table/src/formula"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/exp/slices"
+)
+
+var massFormats = []string{"table", "json"}
+
+type massOptions struct {
+	format string
+}
+
+func massFlags() (*flag.FlagSet, *massOptions) {
+	var o massOptions
+	fs := flag.NewFlagSet("mass", flag.ContinueOnError)
+	fs.StringVar(&o.format, "format", "table", "output format: "+strings.Join(massFormats, ", "))
+	fs.Usage = commandUsage(fs, "mass")
+	return fs, &o
+}
+
+// runMass prints the molar mass and percent composition of a formula. The
+// arguments are joined by spaces, so a charge such as "SO4 2-" needs no
+// quotes.
+func runMass(args []string) int {
+	fs, o := massFlags()
+	words, err := parseArgs(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(words) == 0 {
+		fs.Usage()
+		return 2
+	}
+	if !slices.Contains(massFormats, o.format) {
+		fmt.Fprintf(os.Stderr, "%s mass: unknown format %q, expected one of %s\n", program, o.format, strings.Join(massFormats, ", "))
+		return 2
+	}
+
+	f, err := formula.Parse(strings.Join(words, " "), elements.ReadData())
+	if err != nil {
+		printFormulaError(err)
+		return 2
+	}
+
+	if o.format == "json" {
+		err = writeMassJSON(os.Stdout, f)
+	} else {
+		err = writeMassTable(os.Stdout, f)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s mass: %s\n", program, err)
+		return 1
+	}
+	return 0
+}
+
+// printFormulaError prints err, pointing at the offending part of the
+// formula if it is a parse error.
+func printFormulaError(err error) {
+	var formulaErr *formula.Error
+	if errors.As(err, &formulaErr) {
+		fmt.Fprintln(os.Stderr, formulaErr.Caret())
+	}
+	fmt.Fprintln(os.Stderr, err)
+}
+
+// writeMassTable writes the formula and its molar mass, then a row for
+// every element.
+func writeMassTable(w io.Writer, f formula.Formula) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Formula\t%s\n", f)
+	if f.Charge != 0 {
+		fmt.Fprintf(tw, "Charge\t%+d\n", f.Charge)
+	}
+	fmt.Fprintf(tw, "Molar mass\t%.3f g/mol\n", f.MolarMass())
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Element\tCount\tAtomic mass\tMass\tPercent\t")
+	for _, s := range f.Composition() {
+		fmt.Fprintf(tw, "%s\t%d\t%.3f\t%.3f\t%.2f%%\t\n", s.Symbol(), s.Count, s.Atom.Mass(), s.Mass, s.Percent)
+	}
+	return tw.Flush()
+}
+
+type massShare struct {
+	Symbol     string  `json:"symbol"`
+	Name       string  `json:"name"`
+	MassNumber int     `json:"mass_number,omitempty"`
+	Count      int     `json:"count"`
+	AtomicMass float64 `json:"atomic_mass"`
+	Mass       float64 `json:"mass"`
+	Percent    float64 `json:"percent"`
+}
+
+type massResult struct {
+	Formula     string      `json:"formula"`
+	Hill        string      `json:"hill"`
+	Charge      int         `json:"charge"`
+	MolarMass   float64     `json:"molar_mass"`
+	Composition []massShare `json:"composition"`
+}
+
+func writeMassJSON(w io.Writer, f formula.Formula) error {
+	r := massResult{Formula: f.Source, Hill: f.String(), Charge: f.Charge, MolarMass: f.MolarMass()}
+	for _, s := range f.Composition() {
+		r.Composition = append(r.Composition, massShare{
+			Symbol:     strings.TrimSpace(s.Element.Symbol),
+			Name:       s.Element.Element,
+			MassNumber: s.MassNumber,
+			Count:      s.Count,
+			AtomicMass: s.Atom.Mass(),
+			Mass:       s.Mass,
+			Percent:    s.Percent,
+		})
+	}
+	out, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", out)
+	return err
+}
+
+func completeMass(args []string, word string) []string {
+	fs, _ := massFlags()
+	candidates, _ := completeFlag(fs, map[string]func() []string{"format": constant(massFormats...)}, args, word)
+	return candidates
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"periodic-table/src/config"
+	"periodic-table/src/elements"
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/heatmap"
+	"periodic-table/ui/periodic_table/scheme"
+	"periodic-table/ui/periodic_table/theme"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/exp/slices"
+	"golang.org/x/term"
+)
+
+var printColors = []string{"auto", "always", "never"}
+
+// tableColumns is the number of groups, the columns of the printed table.
+const tableColumns = 18
+
+type printOptions struct {
+	tableOptions
+	width int
+	color string
+}
+
+func printFlags() (*flag.FlagSet, *printOptions) {
+	var o printOptions
+	fs := flag.NewFlagSet("print", flag.ContinueOnError)
+	fs.IntVar(&o.width, "width", 0, "columns to fit the table in, the width of the terminal if 0, which the table overflows if too narrow")
+	fs.StringVar(&o.color, "color", "auto", "draw in color: "+strings.Join(printColors, ", "))
+	o.tableOptions.register(fs)
+	fs.Usage = commandUsage(fs, "print")
+	return fs, &o
+}
+
+// tableOptions are the flags choosing how the table is colored and which
+// elements stand out, shared by the commands drawing the table.
+type tableOptions struct {
+	highlight string
+	scheme    string
+	heatmap   string
+	scale     string
+}
+
+func (o *tableOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.highlight, "highlight", "", "comma separated elements to highlight, such as Fe,Cu")
+	fs.StringVar(&o.scheme, "scheme", "", "color scheme, the one in the config file by default: "+strings.Join(schemeIDs(), ", "))
+	fs.StringVar(&o.heatmap, "heatmap", "", "color by a heatmap of this property instead: "+strings.Join(heatmap.Properties, ", "))
+	fs.StringVar(&o.scale, "scale", "", "heatmap scale: linear, log")
+}
+
+// applyUnits shows values in the units set in c. It comes before the scheme
+// is built, for its legend to be in the same units as the table.
+func applyUnits(c config.Config) {
+	element.SetTemperatureUnit(element.TemperatureUnits[c.Units.Temperature])
+}
+
+// activeScheme returns the scheme chosen by the flags, falling back to the
+// one in the config file.
+func (o tableOptions) activeScheme(c config.Config) (scheme.Scheme, error) {
+	id, property, scale := o.scheme, o.heatmap, o.scale
+	if id == "" {
+		id = c.Scheme
+		if property == "" {
+			property = c.Heatmap
+		}
+	}
+	if scale == "" {
+		scale = c.HeatmapScale
+	}
+
+	i, ok := scheme.Find(id)
+	if !ok {
+		return nil, fmt.Errorf("unknown scheme %q, expected one of %s", id, strings.Join(schemeIDs(), ", "))
+	}
+	if property == "" {
+		return scheme.Schemes[i], nil
+	}
+
+	field, ok := element.FieldByName(property)
+	if !ok || !slices.Contains(heatmap.Properties, property) {
+		return nil, fmt.Errorf("unknown heatmap %q, expected one of %s", property, strings.Join(heatmap.Properties, ", "))
+	}
+	switch scale {
+	case "log":
+		return heatmap.New(field, heatmap.Log, elements.ReadData()), nil
+	case "linear":
+		return heatmap.New(field, heatmap.Linear, elements.ReadData()), nil
+	}
+	return nil, fmt.Errorf("unknown scale %q, expected linear or log", scale)
+}
+
+// highlighted returns the elements named by the highlight flag, reporting
+// those which are unknown. ok is false if any is.
+func (o tableOptions) highlighted(cmd string) (found []element.Data, ok bool) {
+	if o.highlight == "" {
+		return nil, true
+	}
+
+	ok = true
+	all := elements.ReadData()
+	for _, name := range strings.Split(o.highlight, ",") {
+		d, known := element.Find(all, strings.TrimSpace(name))
+		if !known {
+			fmt.Fprintf(os.Stderr, "%s %s: unknown element %q\n", program, cmd, name)
+			ok = false
+			continue
+		}
+		found = append(found, d)
+	}
+	return found, ok
+}
+
+// tableValues are the candidates for the values of the table flags.
+var tableValues = map[string]func() []string{
+	"scheme":    schemeIDs,
+	"heatmap":   constant(heatmap.Properties...),
+	"scale":     constant("linear", "log"),
+	"highlight": elementSymbols,
+}
+
+// runPrint draws the table once to stdout with its legend below, in the
+// largest cells which fit the width.
+func runPrint(args []string) int {
+	fs, o := printFlags()
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(rest) > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	c, _, err := loadConfig(*configPath)
+	if err != nil {
+		printConfigError(err)
+		return 1
+	}
+
+	if !slices.Contains(printColors, o.color) {
+		return printUsageError(fmt.Errorf("unknown color %q, expected one of %s", o.color, strings.Join(printColors, ", ")))
+	}
+	if o.width < 0 {
+		return printUsageError(fmt.Errorf("width must not be negative"))
+	}
+	applyUnits(c)
+	s, err := o.activeScheme(c)
+	if err != nil {
+		return printUsageError(err)
+	}
+
+	theme.Set(c.ResolvedTheme())
+	switch o.color {
+	case "always":
+		theme.ForceColor()
+	case "never":
+		theme.SetProfile(theme.Plain)
+	default:
+		theme.SetProfile(theme.Profiles[c.Profile])
+	}
+	element.SetCellFields(c.Cell.Top, c.Cell.Bottom)
+
+	// Tiny cells have no room for the codes standing in for colors.
+	sizes := element.CellSizes
+	if theme.IsPlain() {
+		sizes = sizes[:len(sizes)-1]
+	}
+	width := o.width
+	if width == 0 {
+		width = terminalWidth()
+	}
+	size, fits := fitCellSize(width, tableColumns, sizes)
+	if !fits && o.width != 0 {
+		return printUsageError(fmt.Errorf("the table needs at least %d columns", size.Width()*tableColumns))
+	}
+	element.SetCellSize(size)
+
+	g, err := grid.CreateModel(elements.ReadElements(), grid.GridSettings{Rows: 10, Columns: tableColumns})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s print: %s\n", program, err)
+		return 1
+	}
+	if active := g.GetActiveCell(); active != nil {
+		(*active).SetSelected(false)
+	}
+	scheme.Apply(s, g.GetCells())
+
+	code := 0
+	highlighted, ok := o.highlighted("print")
+	if !ok {
+		code = 1
+	}
+	for _, c := range g.GetCells() {
+		if d, ok := c.GetData().(element.Data); ok && !c.IsPaddingCell() && isAny(d, highlighted) {
+			c.SetHighlighted(true)
+		}
+	}
+
+	// The grid starts with an empty line.
+	view := g.View()
+	view = view[strings.Index(view, "\n")+1:]
+	fmt.Println(theme.ASCII(view))
+
+	legendWidth := width
+	if w := lipgloss.Width(view); w < legendWidth {
+		legendWidth = w
+	}
+	fmt.Println(theme.ASCII(s.Legend(legendWidth)))
+	return code
+}
+
+// isAny reports whether d is one of data.
+func isAny(d element.Data, data []element.Data) bool {
+	for _, other := range data {
+		if other.AtomicNumber == d.AtomicNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// fitCellSize returns the largest of sizes which fits a row of columns cells
+// in width. If none does, it returns the smallest and false.
+func fitCellSize(width, columns int, sizes []element.CellSize) (element.CellSize, bool) {
+	for _, size := range sizes {
+		if size.Width()*columns <= width {
+			return size, true
+		}
+	}
+	return sizes[len(sizes)-1], false
+}
+
+// terminalWidth returns the width of the terminal on stdout, or of $COLUMNS
+// or 80 columns if stdout is no terminal.
+func terminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
+
+func printUsageError(err error) int {
+	fmt.Fprintf(os.Stderr, "%s print: %s\n", program, err)
+	return 2
+}
+
+func schemeIDs() []string {
+	var ids []string
+	for _, s := range scheme.Schemes {
+		ids = append(ids, s.ID())
+	}
+	return ids
+}
+
+func completePrint(args []string, word string) []string {
+	fs, _ := printFlags()
+	values := map[string]func() []string{"color": constant(printColors...)}
+	for name, candidates := range tableValues {
+		values[name] = candidates
+	}
+	candidates, _ := completeFlag(fs, values, args, word)
+	return candidates
+}
+
+func elementSymbols() []string {
+	var symbols []string
+	for _, d := range elements.ReadData() {
+		symbols = append(symbols, strings.TrimSpace(d.Symbol))
+	}
+	return symbols
+}
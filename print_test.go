@@ -0,0 +1,32 @@
+package main
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"testing"
+)
+
+func TestFitCellSize(t *testing.T) {
+	plain := element.CellSizes[:len(element.CellSizes)-1]
+	tests := []struct {
+		width int
+		sizes []element.CellSize
+		want  element.CellSize
+		fits  bool
+	}{
+		{200, element.CellSizes, element.Normal, true},
+		{144, element.CellSizes, element.Normal, true},
+		{143, element.CellSizes, element.Compact, true},
+		{108, element.CellSizes, element.Compact, true},
+		{80, element.CellSizes, element.Tiny, true},
+		{72, element.CellSizes, element.Tiny, true},
+		{40, element.CellSizes, element.Tiny, false},
+		{108, plain, element.Compact, true},
+		{100, plain, element.Compact, false},
+	}
+	for _, tt := range tests {
+		got, fits := fitCellSize(tt.width, tableColumns, tt.sizes)
+		if got != tt.want || fits != tt.fits {
+			t.Errorf("fitCellSize(%d, %v) = %d, %v, want %d, %v", tt.width, tt.sizes, got, fits, tt.want, tt.fits)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"periodic-table/src/elements"
+	"periodic-table/src/output"
+	"periodic-table/src/query"
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+var queryFormats = []string{"table", "csv", "json", "ndjson"}
+
+// queryColumns are the fields printed when no fields are given, followed by
+// the field sorted by.
+var queryColumns = []string{"atomic_number", "symbol", "name"}
+
+type queryOptions struct {
+	format     string
+	fields     string
+	sort       string
+	descending bool
+	limit      int
+}
+
+func queryFlags() (*flag.FlagSet, *queryOptions) {
+	var o queryOptions
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	fs.StringVar(&o.format, "format", "table", "output format: "+strings.Join(queryFormats, ", "))
+	fs.StringVar(&o.fields, "fields", "", "comma separated fields to print, by default "+strings.Join(queryColumns, ",")+" and the sort field")
+	fs.StringVar(&o.sort, "sort", "", "field to sort by, elements without a value come last")
+	fs.BoolVar(&o.descending, "desc", false, "sort from the highest value down")
+	fs.IntVar(&o.limit, "limit", 0, "print at most this many elements, all if 0")
+	fs.Usage = commandUsage(fs, "query")
+	return fs, &o
+}
+
+// runQuery prints the elements matching a filter expression. Like -filter it
+// exits with 1 if no element matched.
+func runQuery(args []string) int {
+	fs, o := queryFlags()
+	sources, err := parseArgs(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(sources) > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	source := "atomic_number > 0"
+	if len(sources) == 1 {
+		source = sources[0]
+	}
+	q, err := query.Parse(source)
+	if err != nil {
+		printQueryError(err)
+		return 2
+	}
+
+	if !slices.Contains(queryFormats, o.format) {
+		return queryUsageError(fmt.Errorf("unknown format %q, expected one of %s", o.format, strings.Join(queryFormats, ", ")))
+	}
+	if o.limit < 0 {
+		return queryUsageError(fmt.Errorf("limit must not be negative"))
+	}
+	var sortField element.Field
+	if o.sort != "" {
+		var ok bool
+		if sortField, ok = element.FieldByName(o.sort); !ok {
+			return queryUsageError(fmt.Errorf("unknown field %q", o.sort))
+		}
+	}
+
+	list := o.fields
+	if list == "" {
+		list = strings.Join(queryColumns, ",")
+		if o.sort != "" && !slices.Contains(queryColumns, sortField.Name) {
+			list += "," + sortField.Name
+		}
+	}
+	fields, err := parseFields(list)
+	if err != nil {
+		return queryUsageError(err)
+	}
+
+	matches := q.Filter(elements.ReadData())
+	if o.sort != "" {
+		element.Sort(matches, sortField, o.descending)
+	}
+	if o.limit > 0 && len(matches) > o.limit {
+		matches = matches[:o.limit]
+	}
+
+	records := output.Records(fields, matches)
+	switch o.format {
+	case "csv":
+		err = output.WriteCSV(os.Stdout, fields, records)
+	case "json":
+		err = output.WriteJSON(os.Stdout, records)
+	case "ndjson":
+		err = output.WriteNDJSON(os.Stdout, records)
+	default:
+		err = output.WriteTable(os.Stdout, fields, records)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s query: %s\n", program, err)
+		return 2
+	}
+
+	if len(matches) == 0 {
+		return 1
+	}
+	return 0
+}
+
+func queryUsageError(err error) int {
+	fmt.Fprintf(os.Stderr, "%s query: %s\n", program, err)
+	return 2
+}
+
+func completeQuery(args []string, word string) []string {
+	fs, _ := queryFlags()
+	values := map[string]func() []string{"format": constant(queryFormats...), "fields": fieldNames, "sort": fieldNames}
+	candidates, _ := completeFlag(fs, values, args, word)
+	return candidates
+}
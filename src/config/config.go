@@ -0,0 +1,195 @@
+// Package config reads the user's settings from a YAML file.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/scheme"
+	"periodic-table/ui/periodic_table/theme"
+)
+
+// Config holds every setting. A setting missing from the file keeps its
+// value from Default.
+type Config struct {
+	View         string      `yaml:"view"`
+	Theme        string      `yaml:"theme"`
+	Profile      string      `yaml:"profile"`
+	Scheme       string      `yaml:"scheme"`
+	Heatmap      string      `yaml:"heatmap"`
+	HeatmapScale string      `yaml:"heatmap_scale"`
+	Units        Units       `yaml:"units"`
+	InfoPanel    []string    `yaml:"info_panel"`
+	Cell         Cell        `yaml:"cell"`
+	Keys         keys.Remaps `yaml:"keys"`
+
+	theme     theme.Theme
+	themePath string
+}
+
+// ResolvedTheme returns the theme named by the Theme setting.
+func (c Config) ResolvedTheme() theme.Theme {
+	return c.theme
+}
+
+// Files returns the files the settings were read from besides the config
+// file itself, such as a custom theme.
+func (c Config) Files() []string {
+	if c.themePath == "" {
+		return nil
+	}
+	return []string{c.themePath}
+}
+
+type Units struct {
+	Temperature string `yaml:"temperature"`
+}
+
+// Cell sets the fields shown in the top left and bottom right of each cell
+// of the table.
+type Cell struct {
+	Top    string `yaml:"top"`
+	Bottom string `yaml:"bottom"`
+}
+
+// Default returns the settings used when there is no config file.
+func Default() Config {
+	return Config{
+		View:         "table",
+		Theme:        "default",
+		Profile:      "auto",
+		Scheme:       "type",
+		HeatmapScale: "linear",
+		Units:        Units{Temperature: "kelvin"},
+		InfoPanel: []string{"type", "atomic_number", "atomic_mass", "electrons", "protons", "neutrons",
+			"group", "density", "atomic_radius", "melting_point", "specific_heat"},
+		Cell: Cell{Top: "atomic_number", Bottom: "symbol"},
+
+		theme: theme.Default,
+	}
+}
+
+// DefaultPath returns where the config file is read from when no other path
+// is given, inside the user's config directory ($XDG_CONFIG_HOME on Linux).
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "periodic-table", "config.yaml"), nil
+}
+
+// Load reads and validates the config file at path.
+func Load(path string) (Config, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	return Parse(path, source)
+}
+
+// yamlLine finds the line number in the syntax errors of the yaml package.
+var yamlLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// Parse validates source against the schema and returns the settings it
+// holds. Errors are *Error, pointing at the offending line.
+func Parse(path string, source []byte) (Config, error) {
+	config := Default()
+	newError := func(line, column int, msg string) error {
+		return &Error{Path: path, Line: line, Column: column, Msg: msg, source: string(source)}
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(source, &doc); err != nil {
+		return config, syntaxError(path, source, err)
+	}
+	if len(bytes.TrimSpace(source)) == 0 || len(doc.Content) == 0 {
+		return config, nil
+	}
+
+	root := doc.Content[0]
+	if err := validate(root, schema, newError); err != nil {
+		return config, err
+	}
+	if err := root.Decode(&config); err != nil {
+		return config, newError(root.Line, root.Column, err.Error())
+	}
+	if err := keys.Check(config.Keys); err != nil {
+		node := keysNode(root, err)
+		return config, newError(node.Line, node.Column, err.Error())
+	}
+	if err := config.resolveTheme(path); err != nil {
+		if _, ok := err.(*Error); ok {
+			return config, err
+		}
+		node := lookup(root, "theme")
+		return config, newError(node.Line, node.Column, err.Error())
+	}
+	return config, nil
+}
+
+// syntaxError turns an error of the yaml package into an *Error, finding the
+// line in its message.
+func syntaxError(path string, source []byte, err error) error {
+	msg := err.Error()
+	line := 1
+	if m := yamlLine.FindStringSubmatch(msg); m != nil {
+		line, _ = strconv.Atoi(m[1])
+		msg = msg[len(m[0]):]
+	}
+	return &Error{Path: path, Line: line, Column: 1, Msg: msg, source: string(source)}
+}
+
+// ThemesDir returns the directory custom themes are read from for the config
+// file at path.
+func ThemesDir(path string) string {
+	return filepath.Join(filepath.Dir(path), "themes")
+}
+
+// resolveTheme finds the theme named by the Theme setting, either built in
+// or a file in the themes directory next to the config file.
+func (c *Config) resolveTheme(path string) error {
+	if t, ok := theme.Builtin[c.Theme]; ok {
+		c.theme, c.themePath = t, ""
+		return nil
+	}
+
+	themePath := filepath.Join(ThemesDir(path), c.Theme+".yaml")
+	source, err := os.ReadFile(themePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("unknown theme %q, expected one of %s or a file in %s", c.Theme, strings.Join(theme.Names(), ", "), ThemesDir(path))
+	} else if err != nil {
+		return err
+	}
+
+	t, err := theme.Parse(c.Theme, source, schemeCategories())
+	var themeErr *theme.Error
+	if errors.As(err, &themeErr) {
+		return &Error{Path: themePath, Line: themeErr.Line, Column: themeErr.Column, Msg: themeErr.Msg, source: string(source)}
+	} else if err != nil {
+		return syntaxError(themePath, source, err)
+	}
+	c.theme, c.themePath = t, themePath
+	return nil
+}
+
+// schemeCategories returns the values of the categories of every scheme,
+// which a theme can give colors to.
+func schemeCategories() map[string][]string {
+	categories := map[string][]string{}
+	for _, s := range scheme.Schemes {
+		for _, category := range s.Categories() {
+			categories[s.ID()] = append(categories[s.ID()], category.Value)
+		}
+	}
+	return categories
+}
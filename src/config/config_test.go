@@ -0,0 +1,132 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	source := `
+view: list
+scheme: phase
+units:
+  temperature: celsius
+info_panel: [name, density]
+keys:
+  table:
+    pin: [space]
+`
+	got, err := Parse("config.yaml", []byte(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Default()
+	want.View = "list"
+	want.Scheme = "phase"
+	want.Units.Temperature = "celsius"
+	want.InfoPanel = []string{"name", "density"}
+	want.Keys = map[string]map[string][]string{"table": {"pin": {"space"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	got, err := Parse("config.yaml", []byte("# nothing set\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, Default()) {
+		t.Errorf("Parse() = %+v, want the defaults", got)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		source       string
+		line, column int
+	}{
+		{"view: tabel\n", 1, 7},
+		{"scheme: type\ncolour: red\n", 2, 1},
+		{"units:\n  temperature: rankine\n", 2, 16},
+		{"info_panel: [name, densty]\n", 1, 20},
+		{"info_panel: name\n", 1, 13},
+		{"cell:\n  top: mass\n", 2, 8},
+		{"keys:\n  grid:\n    pin: [p]\n", 2, 3},
+		{"keys:\n  table:\n    pinn: [p]\n", 3, 5},
+		{"keys:\n  table:\n    pin: p\n", 3, 10},
+		{"keys:\n  table:\n    search: [p]\n", 3, 5},
+		{"view: table\n  scheme: type\n", 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := Parse("config.yaml", []byte(tt.source))
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("Parse() error = %v, want *Error", err)
+			}
+			if e.Line != tt.line || e.Column != tt.column {
+				t.Errorf("Parse() error at %d:%d, want %d:%d: %v", e.Line, e.Column, tt.line, tt.column, e)
+			}
+		})
+	}
+}
+
+func TestPrintDefaults(t *testing.T) {
+	source, err := PrintDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse("defaults.yaml", source)
+	if err != nil {
+		t.Fatalf("defaults don't parse: %v", err)
+	}
+	got.Keys = nil
+	if !reflect.DeepEqual(got, Default()) {
+		t.Errorf("Parse(PrintDefaults()) = %+v, want the defaults", got)
+	}
+}
+
+func TestLoad_CustomTheme(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	write(t, path, "theme: mine\n")
+	write(t, filepath.Join(dir, "themes", "mine.yaml"), "extends: light\ncolors:\n  error: \"#ff0000\"\n")
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.ResolvedTheme().Name; got != "mine" {
+		t.Errorf("theme = %q, want mine", got)
+	}
+	if got := c.Files(); len(got) != 1 || filepath.Base(got[0]) != "mine.yaml" {
+		t.Errorf("Files() = %v", got)
+	}
+
+	write(t, filepath.Join(dir, "themes", "mine.yaml"), "extends: light\ncolors:\n  error: red\n")
+	_, err = Load(path)
+	var e *Error
+	if !errors.As(err, &e) || filepath.Base(e.Path) != "mine.yaml" || e.Line != 3 {
+		t.Errorf("Load() error = %v, want an error on line 3 of the theme", err)
+	}
+
+	write(t, path, "theme: yours\n")
+	if _, err := Load(path); !errors.As(err, &e) || e.Line != 1 {
+		t.Errorf("Load() error = %v, want an unknown theme error on line 1", err)
+	}
+}
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
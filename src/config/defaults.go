@@ -0,0 +1,112 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/theme"
+)
+
+// PrintDefaults returns a config file holding every setting at its default,
+// each documented by a comment.
+func PrintDefaults() ([]byte, error) {
+	config := Default()
+	config.Keys = keys.Defaults()
+
+	var root yaml.Node
+	if err := root.Encode(config); err != nil {
+		return nil, err
+	}
+	document(&root, schema)
+	root.HeadComment = "Settings of periodic-table. Every setting is optional."
+
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&root); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), encoder.Close()
+}
+
+// document adds the doc of each setting as a comment above it and writes
+// lists on a single line.
+func document(node *yaml.Node, settings []setting) {
+	for i := 0; i < len(node.Content); i += 2 {
+		name, value := node.Content[i], node.Content[i+1]
+		s, ok := find(settings, name.Value)
+		if !ok {
+			continue
+		}
+		name.HeadComment = s.doc
+		if s.settings != nil {
+			document(value, s.settings)
+		}
+		flowLists(value)
+	}
+}
+
+func flowLists(node *yaml.Node) {
+	if node.Kind == yaml.SequenceNode {
+		node.Style = yaml.FlowStyle
+		return
+	}
+	for _, child := range node.Content {
+		flowLists(child)
+	}
+}
+
+// JSONSchema returns a JSON schema of the config file, for editors which
+// can check YAML against one.
+func JSONSchema() ([]byte, error) {
+	root := objectSchema(schema)
+	root["$schema"] = "http://json-schema.org/draft-07/schema#"
+	root["title"] = "periodic-table config"
+	return json.MarshalIndent(root, "", "  ")
+}
+
+type object = map[string]interface{}
+
+func objectSchema(settings []setting) object {
+	properties := object{}
+	for _, s := range settings {
+		properties[s.name] = settingSchema(s)
+	}
+	return object{"type": "object", "properties": properties, "additionalProperties": false}
+}
+
+func settingSchema(s setting) object {
+	var schema object
+	switch {
+	case s.schema != nil:
+		schema = s.schema()
+	case s.settings != nil:
+		schema = objectSchema(s.settings)
+	case s.list:
+		schema = object{"type": "array", "items": object{"enum": s.values()}}
+	default:
+		schema = object{"enum": s.values()}
+	}
+	schema["description"] = s.doc
+	return schema
+}
+
+func themeSchema() object {
+	return object{"type": "string", "examples": theme.Names()}
+}
+
+func keysSchema() object {
+	keyList := object{"type": "array", "items": object{"type": "string", "minLength": 1}}
+	views := object{}
+	for _, view := range keys.Maps() {
+		actions := object{}
+		names, _ := keys.Actions(view)
+		for _, action := range names {
+			actions[action] = keyList
+		}
+		views[view] = object{"type": "object", "properties": actions, "additionalProperties": false}
+	}
+	return object{"type": "object", "properties": views, "additionalProperties": false}
+}
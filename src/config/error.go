@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is returned for a config file which can't be used. Line and Column
+// locate the offending value, counted from one.
+type Error struct {
+	Path   string
+	Line   int
+	Column int
+	Msg    string
+
+	source string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.Path, e.Line, e.Column, e.Msg)
+}
+
+// Caret returns the offending line with a marker under the offending column.
+func (e *Error) Caret() string {
+	lines := strings.Split(e.source, "\n")
+	if e.Line < 1 || e.Line > len(lines) {
+		return ""
+	}
+	line := strings.TrimRight(lines[e.Line-1], "\r")
+	column := e.Column - 1
+	if column < 0 {
+		column = 0
+	}
+	return line + "\n" + strings.Repeat(" ", column) + "^"
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/heatmap"
+	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/scheme"
+)
+
+// setting describes one key of the config file. A setting is either a
+// string, a list of strings, a mapping of further settings or, when validate
+// is set, checked by its own function and described by schema. what names
+// the values in errors and defaults to the name of the setting.
+type setting struct {
+	name     string
+	doc      string
+	what     string
+	values   func() []string
+	list     bool
+	settings []setting
+	validate func(node *yaml.Node, newError errorFunc) error
+	schema   func() object
+}
+
+type errorFunc func(line, column int, msg string) error
+
+// schema describes the config file. It is used to validate files, to
+// document the defaults and to generate a JSON schema for editors.
+var schema = []setting{
+	{name: "view", doc: "View shown at startup: table, list or accessible, which describes the table line by line for screen readers.", values: constant("table", "list", "accessible")},
+	{name: "theme", doc: "Colors: default, dark, light, high-contrast, okabe-ito or the name of a file in the themes directory beside this file.", validate: validateString, schema: themeSchema},
+	{name: "profile", doc: "Drawing: auto, color or plain. plain has no color and ASCII borders, auto picks it when NO_COLOR is set or the terminal has no colors.", values: constant("auto", "color", "plain")},
+	{name: "scheme", doc: "Categorical color scheme of the table.", values: schemeIDs},
+	{name: "heatmap", doc: "Numeric property to color the table by instead of the scheme, empty for none.", values: heatmapProperties},
+	{name: "heatmap_scale", doc: "Scale of the heatmap: linear or log.", values: constant("linear", "log")},
+	{name: "units", doc: "Units values are shown in. Filters compare temperatures in kelvin whatever the unit.", settings: []setting{
+		{name: "temperature", doc: "kelvin, celsius or fahrenheit.", values: constant("kelvin", "celsius", "fahrenheit")},
+	}},
+	{name: "info_panel", doc: "Fields listed in the panel beside the table.", what: "field", values: fieldNames, list: true},
+	{name: "cell", doc: "Fields shown in each cell of the table.", settings: []setting{
+		{name: "top", doc: "Top left of the cell.", what: "field", values: fieldNames},
+		{name: "bottom", doc: "Bottom right of the cell.", what: "field", values: fieldNames},
+	}},
+	{name: "keys", doc: "Keys of each action, by view. An empty list disables an action.", validate: validateKeys, schema: keysSchema},
+}
+
+func constant(values ...string) func() []string {
+	return func() []string { return values }
+}
+
+func schemeIDs() []string {
+	var ids []string
+	for _, s := range scheme.Schemes {
+		ids = append(ids, s.ID())
+	}
+	return ids
+}
+
+func heatmapProperties() []string {
+	return append([]string{""}, heatmap.Properties...)
+}
+
+func fieldNames() []string {
+	var names []string
+	for _, f := range element.Fields {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func validate(node *yaml.Node, settings []setting, newError errorFunc) error {
+	if node.Kind != yaml.MappingNode {
+		return newError(node.Line, node.Column, "expected a mapping of settings")
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		name, value := node.Content[i], node.Content[i+1]
+		s, ok := find(settings, name.Value)
+		if !ok {
+			return newError(name.Line, name.Column, fmt.Sprintf("unknown setting %q, expected one of %s", name.Value, strings.Join(names(settings), ", ")))
+		}
+
+		var err error
+		switch {
+		case s.validate != nil:
+			err = s.validate(value, newError)
+		case s.settings != nil:
+			err = validate(value, s.settings, newError)
+		case s.list:
+			if value.Kind != yaml.SequenceNode {
+				return newError(value.Line, value.Column, fmt.Sprintf("%s must be a list", s.name))
+			}
+			for _, item := range value.Content {
+				if err = validateValue(item, s, newError); err != nil {
+					break
+				}
+			}
+		default:
+			err = validateValue(value, s, newError)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateValue(node *yaml.Node, s setting, newError errorFunc) error {
+	if node.Kind != yaml.ScalarNode {
+		return newError(node.Line, node.Column, fmt.Sprintf("%s must be a string", s.name))
+	}
+
+	value := node.Value
+	if node.Tag == "!!null" {
+		value = ""
+	}
+	for _, allowed := range s.values() {
+		if value == allowed {
+			return nil
+		}
+	}
+	what := s.what
+	if what == "" {
+		what = s.name
+	}
+	return newError(node.Line, node.Column, fmt.Sprintf("%q is not a valid %s, expected one of %s", value, what, strings.Join(s.values(), ", ")))
+}
+
+// validateString accepts any string. The theme setting is checked once the
+// file is decoded, as custom themes depend on where the file is.
+func validateString(node *yaml.Node, newError errorFunc) error {
+	if node.Kind != yaml.ScalarNode {
+		return newError(node.Line, node.Column, "expected a string")
+	}
+	return nil
+}
+
+func validateKeys(node *yaml.Node, newError errorFunc) error {
+	if node.Kind != yaml.MappingNode {
+		return newError(node.Line, node.Column, "keys must be a mapping of views")
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		view, actions := node.Content[i], node.Content[i+1]
+		names, ok := keys.Actions(view.Value)
+		if !ok {
+			return newError(view.Line, view.Column, fmt.Sprintf("unknown view %q, expected one of %s", view.Value, strings.Join(keys.Maps(), ", ")))
+		}
+		if actions.Kind != yaml.MappingNode {
+			return newError(actions.Line, actions.Column, fmt.Sprintf("keys of %s must be a mapping of actions", view.Value))
+		}
+
+		for j := 0; j < len(actions.Content); j += 2 {
+			action, bound := actions.Content[j], actions.Content[j+1]
+			if !slices.Contains(names, action.Value) {
+				return newError(action.Line, action.Column, fmt.Sprintf("unknown %s action %q", view.Value, action.Value))
+			}
+			if bound.Kind != yaml.SequenceNode {
+				return newError(bound.Line, bound.Column, fmt.Sprintf("keys of %s must be a list", action.Value))
+			}
+			for _, k := range bound.Content {
+				if k.Kind != yaml.ScalarNode || k.Value == "" {
+					return newError(k.Line, k.Column, "a key must be a non-empty string")
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// keysNode returns the node a key conflict should be reported at: the second
+// of the conflicting actions if it was remapped, otherwise the first.
+func keysNode(root *yaml.Node, err error) *yaml.Node {
+	node := lookup(root, "keys")
+	conflict, ok := err.(*keys.ConflictError)
+	if node == nil || !ok {
+		return root
+	}
+	view := lookup(node, conflict.Map)
+	if view == nil {
+		return node
+	}
+	for i := len(conflict.Actions) - 1; i >= 0; i-- {
+		for j := 0; j < len(view.Content); j += 2 {
+			if view.Content[j].Value == conflict.Actions[i] {
+				return view.Content[j]
+			}
+		}
+	}
+	return node
+}
+
+// lookup returns the value of the named key of a mapping node.
+func lookup(node *yaml.Node, name string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func find(settings []setting, name string) (setting, bool) {
+	for _, s := range settings {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+func names(settings []setting) []string {
+	var names []string
+	for _, s := range settings {
+		names = append(names, s.name)
+	}
+	return names
+}
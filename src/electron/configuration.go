@@ -0,0 +1,301 @@
+// Package electron works out the ground state electron configuration of
+// atoms and their ions, as filled by the Aufbau principle with the known
+// exceptions, and how the electrons sit in the orbitals by Hund's rules.
+package electron
+
+import (
+	"fmt"
+	"periodic-table/src/formula"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxAtomicNumber is the heaviest element configurations are known for.
+const MaxAtomicNumber = 118
+
+// ShellNames are the letters of the shells from N = 1 outwards.
+const ShellNames = "KLMNOPQ"
+
+// letters name subshells by their angular momentum quantum number.
+const letters = "spdfg"
+
+// Subshell is the electrons of an atom which share the principal quantum
+// number N and the angular momentum quantum number L.
+type Subshell struct {
+	N, L      int
+	Electrons int
+}
+
+// Capacity returns the number of electrons the subshell holds when full,
+// two in each of its orbitals.
+func (s Subshell) Capacity() int {
+	return 2 * (2*s.L + 1)
+}
+
+// Name returns the name of the subshell, such as "3d".
+func (s Subshell) Name() string {
+	return strconv.Itoa(s.N) + string(letters[s.L])
+}
+
+// String returns the subshell with its electrons in superscripts, such as
+// "3d⁶".
+func (s Subshell) String() string {
+	return s.Name() + formula.Superscript(strconv.Itoa(s.Electrons))
+}
+
+// Configuration is the occupied subshells of an atom or ion, in the order
+// of N and then L, as in "1s² 2s² 2p⁶ 3s² 3p⁶ 3d⁶ 4s²".
+type Configuration []Subshell
+
+// aufbau is the order in which subshells fill: by increasing N+L, and by
+// increasing N for the same N+L (the Madelung rule).
+var aufbau = func() []Subshell {
+	var order []Subshell
+	for n := 1; n <= 7; n++ {
+		for l := 0; l < n && l < 4; l++ {
+			order = append(order, Subshell{N: n, L: l})
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if a.N+a.L != b.N+b.L {
+			return a.N+a.L < b.N+b.L
+		}
+		return a.N < b.N
+	})
+	return order
+}()
+
+// exceptions are the elements whose ground state breaks the Madelung rule,
+// with the electrons of the subshells which differ from it.
+var exceptions = map[int]map[string]int{
+	24:  {"3d": 5, "4s": 1},
+	29:  {"3d": 10, "4s": 1},
+	41:  {"4d": 4, "5s": 1},
+	42:  {"4d": 5, "5s": 1},
+	44:  {"4d": 7, "5s": 1},
+	45:  {"4d": 8, "5s": 1},
+	46:  {"4d": 10, "5s": 0},
+	47:  {"4d": 10, "5s": 1},
+	57:  {"4f": 0, "5d": 1},
+	58:  {"4f": 1, "5d": 1},
+	64:  {"4f": 7, "5d": 1},
+	78:  {"5d": 9, "6s": 1},
+	79:  {"5d": 10, "6s": 1},
+	89:  {"5f": 0, "6d": 1},
+	90:  {"5f": 0, "6d": 2},
+	91:  {"5f": 2, "6d": 1},
+	92:  {"5f": 3, "6d": 1},
+	93:  {"5f": 4, "6d": 1},
+	96:  {"5f": 7, "6d": 1},
+	103: {"6d": 0, "7p": 1},
+}
+
+// nobleGases are the atomic numbers and symbols of the noble gases, whose
+// configurations stand for the core of heavier atoms.
+var nobleGases = []struct {
+	z      int
+	symbol string
+}{{86, "Rn"}, {54, "Xe"}, {36, "Kr"}, {18, "Ar"}, {10, "Ne"}, {2, "He"}}
+
+// Configure returns the ground state configuration of the neutral atom with
+// atomic number z.
+func Configure(z int) (Configuration, error) {
+	if z < 1 || z > MaxAtomicNumber {
+		return nil, fmt.Errorf("no electron configuration for atomic number %d", z)
+	}
+
+	c := fill(nil, z)
+	for name, electrons := range exceptions[z] {
+		c = c.set(name, electrons)
+	}
+	return c.normalize(), nil
+}
+
+// Ion returns the ground state configuration of the ion of the atom with
+// atomic number z and the given charge. Cations lose their outermost
+// electrons first, so iron loses its 4s electrons before its 3d ones and
+// europium its 6s ones before its 4f ones, and anions gain them in Aufbau
+// order.
+func Ion(z, charge int) (Configuration, error) {
+	c, err := Configure(z)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case charge > z:
+		return nil, fmt.Errorf("an atom with atomic number %d has only %d electrons to lose", z, z)
+	case charge > 0:
+		c = c.remove(charge)
+	case charge < 0:
+		c = fill(c, -charge)
+	}
+	return c.normalize(), nil
+}
+
+// fill adds electrons to the first subshells in Aufbau order which have
+// room for them.
+func fill(c Configuration, electrons int) Configuration {
+	for _, s := range aufbau {
+		if electrons == 0 {
+			break
+		}
+		held := c.electrons(s.Name())
+		add := s.Capacity() - held
+		if add > electrons {
+			add = electrons
+		}
+		if add > 0 {
+			c = c.set(s.Name(), held+add)
+			electrons -= add
+		}
+	}
+	return c
+}
+
+// remove takes electrons away from the outermost subshells: np and ns of
+// the highest N, then (n-1)d and then (n-2)f, before the shell below.
+func (c Configuration) remove(electrons int) Configuration {
+	for electrons > 0 && len(c) > 0 {
+		last := 0
+		for i, s := range c {
+			if removedBefore(s, c[last]) {
+				last = i
+			}
+		}
+		take := c[last].Electrons
+		if take > electrons {
+			take = electrons
+		}
+		c = c.set(c[last].Name(), c[last].Electrons-take).normalize()
+		electrons -= take
+	}
+	return c
+}
+
+// removedBefore reports whether an ion loses the electrons of a before those
+// of b. A d subshell counts as part of the shell above it and an f subshell
+// as part of the shell two above, and within a shell p electrons go first,
+// then s, d and f ones.
+func removedBefore(a, b Subshell) bool {
+	if level(a) != level(b) {
+		return level(a) > level(b)
+	}
+	return removalOrder[a.L] < removalOrder[b.L]
+}
+
+// level returns the shell whose electrons are lost along with those of s.
+func level(s Subshell) int {
+	if s.L < 2 {
+		return s.N
+	}
+	return s.N + s.L - 1
+}
+
+// removalOrder ranks the subshells of a shell by the order they lose
+// electrons, indexed by L.
+var removalOrder = []int{1, 0, 2, 3}
+
+func (c Configuration) electrons(name string) int {
+	for _, s := range c {
+		if s.Name() == name {
+			return s.Electrons
+		}
+	}
+	return 0
+}
+
+// set returns c with the subshell named name holding electrons.
+func (c Configuration) set(name string, electrons int) Configuration {
+	c = append(Configuration{}, c...)
+	for i := range c {
+		if c[i].Name() == name {
+			c[i].Electrons = electrons
+			return c
+		}
+	}
+	n, _ := strconv.Atoi(name[:len(name)-1])
+	l := strings.IndexByte(letters, name[len(name)-1])
+	return append(c, Subshell{N: n, L: l, Electrons: electrons})
+}
+
+// normalize drops empty subshells and sorts the others by N and L.
+func (c Configuration) normalize() Configuration {
+	var out Configuration
+	for _, s := range c {
+		if s.Electrons > 0 {
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].N != out[j].N {
+			return out[i].N < out[j].N
+		}
+		return out[i].L < out[j].L
+	})
+	return out
+}
+
+// Electrons returns the number of electrons of the configuration.
+func (c Configuration) Electrons() int {
+	var total int
+	for _, s := range c {
+		total += s.Electrons
+	}
+	return total
+}
+
+// Shells returns the number of electrons in each shell, from the K shell
+// (N = 1) outwards.
+func (c Configuration) Shells() []int {
+	var shells []int
+	for _, s := range c {
+		for len(shells) < s.N {
+			shells = append(shells, 0)
+		}
+		shells[s.N-1] += s.Electrons
+	}
+	return shells
+}
+
+// String returns the full configuration, such as "1s² 2s² 2p⁴".
+func (c Configuration) String() string {
+	parts := make([]string, len(c))
+	for i, s := range c {
+		parts[i] = s.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// Condensed returns the configuration with its core written as the noble
+// gas it matches, such as "[Ar] 3d⁶ 4s²".
+func (c Configuration) Condensed() string {
+	for _, gas := range nobleGases {
+		if gas.z >= c.Electrons() {
+			continue
+		}
+		core, _ := Configure(gas.z)
+		if !c.contains(core) {
+			continue
+		}
+		rest := Configuration{}
+		for _, s := range c {
+			if core.electrons(s.Name()) == 0 {
+				rest = append(rest, s)
+			}
+		}
+		return strings.TrimSpace("[" + gas.symbol + "] " + rest.String())
+	}
+	return c.String()
+}
+
+// contains reports whether c has every subshell of core, as full.
+func (c Configuration) contains(core Configuration) bool {
+	for _, s := range core {
+		if c.electrons(s.Name()) != s.Electrons {
+			return false
+		}
+	}
+	return true
+}
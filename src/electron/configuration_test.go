@@ -0,0 +1,104 @@
+package electron
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		z             int
+		want          string
+		wantCondensed string
+	}{
+		{z: 1, want: "1s¹", wantCondensed: "1s¹"},
+		{z: 2, want: "1s²", wantCondensed: "1s²"},
+		{z: 8, want: "1s² 2s² 2p⁴", wantCondensed: "[He] 2s² 2p⁴"},
+		{z: 18, want: "1s² 2s² 2p⁶ 3s² 3p⁶", wantCondensed: "[Ne] 3s² 3p⁶"},
+		{z: 26, want: "1s² 2s² 2p⁶ 3s² 3p⁶ 3d⁶ 4s²", wantCondensed: "[Ar] 3d⁶ 4s²"},
+		{z: 24, wantCondensed: "[Ar] 3d⁵ 4s¹"},
+		{z: 29, wantCondensed: "[Ar] 3d¹⁰ 4s¹"},
+		{z: 46, wantCondensed: "[Kr] 4d¹⁰"},
+		{z: 64, wantCondensed: "[Xe] 4f⁷ 5d¹ 6s²"},
+		{z: 79, wantCondensed: "[Xe] 4f¹⁴ 5d¹⁰ 6s¹"},
+		{z: 92, wantCondensed: "[Rn] 5f³ 6d¹ 7s²"},
+		{z: 103, wantCondensed: "[Rn] 5f¹⁴ 7s² 7p¹"},
+		{z: 118, wantCondensed: "[Rn] 5f¹⁴ 6d¹⁰ 7s² 7p⁶"},
+	}
+	for _, tt := range tests {
+		c, err := Configure(tt.z)
+		if err != nil {
+			t.Fatalf("Configure(%d) error = %v", tt.z, err)
+		}
+		if c.Electrons() != tt.z {
+			t.Errorf("Configure(%d) has %d electrons", tt.z, c.Electrons())
+		}
+		if tt.want != "" && c.String() != tt.want {
+			t.Errorf("Configure(%d) = %s, want %s", tt.z, c, tt.want)
+		}
+		if got := c.Condensed(); got != tt.wantCondensed {
+			t.Errorf("Configure(%d).Condensed() = %s, want %s", tt.z, got, tt.wantCondensed)
+		}
+	}
+
+	for _, z := range []int{0, 119} {
+		if _, err := Configure(z); err == nil {
+			t.Errorf("Configure(%d) error = nil", z)
+		}
+	}
+}
+
+func TestConfiguration_Shells(t *testing.T) {
+	tests := []struct {
+		z    int
+		want []int
+	}{
+		{z: 1, want: []int{1}},
+		{z: 11, want: []int{2, 8, 1}},
+		{z: 26, want: []int{2, 8, 14, 2}},
+		{z: 118, want: []int{2, 8, 18, 32, 32, 18, 8}},
+	}
+	for _, tt := range tests {
+		c, _ := Configure(tt.z)
+		if got := c.Shells(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Configure(%d).Shells() = %v, want %v", tt.z, got, tt.want)
+		}
+	}
+}
+
+func TestIon(t *testing.T) {
+	tests := []struct {
+		z, charge int
+		want      string
+	}{
+		{z: 26, charge: 2, want: "[Ar] 3d⁶"},
+		{z: 26, charge: 3, want: "[Ar] 3d⁵"},
+		{z: 29, charge: 1, want: "[Ar] 3d¹⁰"},
+		{z: 50, charge: 2, want: "[Kr] 4d¹⁰ 5s²"},
+		{z: 11, charge: 1, want: "[He] 2s² 2p⁶"},
+		{z: 17, charge: -1, want: "[Ne] 3s² 3p⁶"},
+		{z: 8, charge: -2, want: "[He] 2s² 2p⁶"},
+		{z: 81, charge: 3, want: "[Xe] 4f¹⁴ 5d¹⁰"},
+		{z: 58, charge: 4, want: "[Kr] 4d¹⁰ 5s² 5p⁶"},
+		{z: 62, charge: 3, want: "[Xe] 4f⁵"},
+		{z: 63, charge: 3, want: "[Xe] 4f⁶"},
+		{z: 64, charge: 3, want: "[Xe] 4f⁷"},
+		{z: 70, charge: 3, want: "[Xe] 4f¹³"},
+		{z: 92, charge: 4, want: "[Rn] 5f²"},
+		{z: 94, charge: 3, want: "[Rn] 5f⁵"},
+		{z: 1, charge: 1, want: ""},
+	}
+	for _, tt := range tests {
+		c, err := Ion(tt.z, tt.charge)
+		if err != nil {
+			t.Fatalf("Ion(%d, %d) error = %v", tt.z, tt.charge, err)
+		}
+		if got := c.Condensed(); got != tt.want {
+			t.Errorf("Ion(%d, %d) = %s, want %s", tt.z, tt.charge, got, tt.want)
+		}
+	}
+
+	if _, err := Ion(8, 9); err == nil {
+		t.Error("Ion(8, 9) error = nil")
+	}
+}
@@ -0,0 +1,90 @@
+package electron
+
+import (
+	"periodic-table/src/formula"
+	"strconv"
+)
+
+// termLetters name the total orbital angular momentum L of a term, skipping
+// J which stands for the total angular momentum.
+const termLetters = "SPDFGHIKLMNOQRTUV"
+
+// Orbitals returns the number of electrons in each orbital of the subshell,
+// from magnetic quantum number +L down to -L. By Hund's rule every orbital
+// takes one electron, with parallel spins, before any takes a second.
+func (s Subshell) Orbitals() []int {
+	orbitals := make([]int, 2*s.L+1)
+	for e := 0; e < s.Electrons; e++ {
+		orbitals[e%len(orbitals)]++
+	}
+	return orbitals
+}
+
+// Unpaired returns the number of orbitals of the subshell with a single
+// electron.
+func (s Subshell) Unpaired() int {
+	var unpaired int
+	for _, n := range s.Orbitals() {
+		if n == 1 {
+			unpaired++
+		}
+	}
+	return unpaired
+}
+
+// Unpaired returns the number of unpaired electrons of the configuration.
+func (c Configuration) Unpaired() int {
+	var unpaired int
+	for _, s := range c {
+		unpaired += s.Unpaired()
+	}
+	return unpaired
+}
+
+// Paramagnetic reports whether the configuration has unpaired electrons,
+// which draw it into a magnetic field. Configurations without are
+// diamagnetic.
+func (c Configuration) Paramagnetic() bool {
+	return c.Unpaired() > 0
+}
+
+// TermSymbol returns the ground state term symbol predicted by Hund's rules,
+// such as "⁵D₄" for iron: the highest total spin S, then the highest total
+// orbital angular momentum L, and a total angular momentum J of |L - S| for
+// open subshells up to half full and L + S beyond. Full subshells make no
+// contribution. Some heavy atoms, whose open subshells interact more, have
+// other ground terms.
+func (c Configuration) TermSymbol() string {
+	// Spins and momenta are counted in halves to stay whole.
+	var spin2, orbital, open, capacity int
+	for _, s := range c {
+		if s.Electrons == s.Capacity() {
+			continue
+		}
+		spin2 += s.Unpaired()
+		up := s.Electrons
+		if up > 2*s.L+1 {
+			up = 2*s.L + 1
+		}
+		for i := 0; i < up; i++ {
+			orbital += s.L - i
+		}
+		for i := 0; i < s.Electrons-up; i++ {
+			orbital += s.L - i
+		}
+		open += s.Electrons
+		capacity += s.Capacity()
+	}
+
+	j2 := 2*orbital - spin2
+	if 2*open > capacity {
+		j2 = 2*orbital + spin2
+	} else if j2 < 0 {
+		j2 = -j2
+	}
+	j := strconv.Itoa(j2 / 2)
+	if j2%2 != 0 {
+		j = strconv.Itoa(j2) + "/2"
+	}
+	return formula.Superscript(strconv.Itoa(spin2+1)) + string(termLetters[orbital]) + formula.Subscript(j)
+}
@@ -0,0 +1,58 @@
+package electron
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubshell_Orbitals(t *testing.T) {
+	tests := []struct {
+		s    Subshell
+		want []int
+	}{
+		{s: Subshell{N: 2, L: 1, Electrons: 2}, want: []int{1, 1, 0}},
+		{s: Subshell{N: 2, L: 1, Electrons: 4}, want: []int{2, 1, 1}},
+		{s: Subshell{N: 3, L: 2, Electrons: 6}, want: []int{2, 1, 1, 1, 1}},
+		{s: Subshell{N: 4, L: 0, Electrons: 2}, want: []int{2}},
+	}
+	for _, tt := range tests {
+		if got := tt.s.Orbitals(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s Orbitals() = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestConfiguration_TermSymbol(t *testing.T) {
+	tests := []struct {
+		z, charge    int
+		wantUnpaired int
+		wantTerm     string
+	}{
+		{z: 1, wantUnpaired: 1, wantTerm: "²S₁/₂"},
+		{z: 2, wantUnpaired: 0, wantTerm: "¹S₀"},
+		{z: 6, wantUnpaired: 2, wantTerm: "³P₀"},
+		{z: 7, wantUnpaired: 3, wantTerm: "⁴S₃/₂"},
+		{z: 8, wantUnpaired: 2, wantTerm: "³P₂"},
+		{z: 9, wantUnpaired: 1, wantTerm: "²P₃/₂"},
+		{z: 24, wantUnpaired: 6, wantTerm: "⁷S₃"},
+		{z: 26, wantUnpaired: 4, wantTerm: "⁵D₄"},
+		{z: 26, charge: 3, wantUnpaired: 5, wantTerm: "⁶S₅/₂"},
+		{z: 29, charge: 2, wantUnpaired: 1, wantTerm: "²D₅/₂"},
+		{z: 64, wantUnpaired: 8, wantTerm: "⁹D₂"},
+	}
+	for _, tt := range tests {
+		c, err := Ion(tt.z, tt.charge)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.Unpaired(); got != tt.wantUnpaired {
+			t.Errorf("Ion(%d, %d).Unpaired() = %d, want %d", tt.z, tt.charge, got, tt.wantUnpaired)
+		}
+		if got := c.Paramagnetic(); got != (tt.wantUnpaired > 0) {
+			t.Errorf("Ion(%d, %d).Paramagnetic() = %v", tt.z, tt.charge, got)
+		}
+		if got := c.TermSymbol(); got != tt.wantTerm {
+			t.Errorf("Ion(%d, %d).TermSymbol() = %s, want %s", tt.z, tt.charge, got, tt.wantTerm)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"periodic-table/ui/grid"
 	"periodic-table/ui/periodic_table/element"
+	"strings"
 )
 
 var (
@@ -19,6 +20,21 @@ var (
 )
 
 func ReadElements() []grid.Cell {
+	return createElements(readCSV())
+}
+
+// ReadData returns the data of every element in the order of the csv file.
+func ReadData() []element.Data {
+	var elements []element.Data
+	for i, line := range readCSV() {
+		if i > 0 {
+			elements = append(elements, createElementData(line))
+		}
+	}
+	return elements
+}
+
+func readCSV() [][]string {
 	// open file
 	f, err := os.Open("data/elements.csv")
 	if err != nil {
@@ -40,8 +56,7 @@ func ReadElements() []grid.Cell {
 		log.Fatal(err)
 	}
 
-	// convert records to array of structs
-	return createElements(data)
+	return data
 }
 
 func createElements(data [][]string) []grid.Cell {
@@ -70,6 +85,13 @@ func createElements(data [][]string) []grid.Cell {
 }
 
 func createElementData(line []string) element.Data {
+	for i := range line {
+		line[i] = strings.TrimSpace(line[i])
+	}
+	if line[9] == "liq" {
+		line[9] = "liquid"
+	}
+
 	return element.Data{
 		AtomicNumber:      line[0],
 		Element:           line[1],
@@ -0,0 +1,149 @@
+// Package export draws the table for handouts and the web, as a standalone
+// HTML page, an SVG image or a PNG image. Cells are colored by the scheme in
+// the colors of the current theme, the same colors the terminal shows.
+package export
+
+import (
+	"fmt"
+	"io"
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/heatmap"
+	"periodic-table/ui/periodic_table/scheme"
+	"periodic-table/ui/periodic_table/theme"
+	"strings"
+)
+
+// Formats are the formats the table can be exported in.
+var Formats = []string{"html", "svg", "png"}
+
+// title heads every export.
+const title = "Periodic Table of the Elements"
+
+// Table is the table as it is exported: the elements in their places in the
+// colors of a scheme, and the legend explaining the colors.
+type Table struct {
+	Title         string
+	Rows, Columns int
+	Cells         []Cell
+	Legend        Legend
+	// Dark draws on a dark background, in the colors themes give dark
+	// terminals.
+	Dark bool
+}
+
+// Cell is an element of the table. Colors are hex colors, and Fill is empty
+// if the scheme has no value for the element.
+type Cell struct {
+	Row, Column int
+	Number      string
+	Symbol      string
+	Name        string
+	Mass        string
+	Fill        string
+	Text        string
+	Highlighted bool
+}
+
+// Legend explains the colors of a scheme, by a color for each category or by
+// a gradient from the lowest to the highest value of a heatmap.
+type Legend struct {
+	Title     string
+	Items     []Item
+	Gradient  []string
+	Low, High string
+}
+
+// Item is a category of the legend.
+type Item struct {
+	Label string
+	Color string
+}
+
+// gradientStops is the number of colors a heatmap's gradient is sampled at.
+const gradientStops = 11
+
+// New lays out cells, the cells of the table's grid in rows of columns, in
+// the colors of s. Elements for which highlighted is true are outlined.
+func New(s scheme.Scheme, cells []grid.Cell, columns int, highlighted func(element.Data) bool, dark bool) Table {
+	t := Table{Title: title, Rows: len(cells) / columns, Columns: columns, Dark: dark}
+	for i, c := range cells {
+		d, ok := c.GetData().(element.Data)
+		if !ok || c.IsPaddingCell() {
+			continue
+		}
+
+		cell := Cell{
+			Row:         i / columns,
+			Column:      i % columns,
+			Number:      d.AtomicNumber,
+			Symbol:      strings.TrimSpace(d.Symbol),
+			Name:        d.Element,
+			Mass:        d.AtomicMass,
+			Highlighted: highlighted(d),
+		}
+		if color, ok := s.Color(d); ok {
+			cell.Fill, _ = theme.Hex(color, dark)
+			cell.Text, _ = theme.Hex(theme.TextOn(color), dark)
+		}
+		t.Cells = append(t.Cells, cell)
+	}
+
+	switch s := s.(type) {
+	case scheme.Categorical:
+		t.Legend.Title = s.Name()
+		for _, category := range s.Categories() {
+			color, _ := theme.Hex(category.Color, dark)
+			t.Legend.Items = append(t.Legend.Items, Item{Label: category.Label, Color: color})
+		}
+	case heatmap.Heatmap:
+		t.Legend.Title = s.Title()
+		for i := 0; i < gradientStops; i++ {
+			t.Legend.Gradient = append(t.Legend.Gradient, string(heatmap.ColorAt(float64(i)/(gradientStops-1))))
+		}
+		t.Legend.Low, t.Legend.High = s.Range()
+	default:
+		t.Legend.Title = s.Name()
+	}
+	return t
+}
+
+// Write writes the table in format, one of Formats.
+func (t Table) Write(w io.Writer, format string) error {
+	switch format {
+	case "html":
+		return t.WriteHTML(w)
+	case "svg":
+		return t.WriteSVG(w)
+	case "png":
+		return t.WritePNG(w)
+	}
+	return fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(Formats, ", "))
+}
+
+// palette is the colors of the page around the cells.
+type palette struct {
+	background, text, noData string
+}
+
+func (t Table) palette() palette {
+	noData, ok := theme.Hex(theme.Current().NoData, t.Dark)
+	if !ok {
+		noData = "#808080"
+	}
+	if t.Dark {
+		return palette{background: "#1c1c1c", text: "#eeeeee", noData: noData}
+	}
+	return palette{background: "#ffffff", text: "#1a1a1a", noData: noData}
+}
+
+// hasRow reports whether any cell is in row. Empty rows, such as the one
+// above the lanthanides, are drawn as a narrow gap.
+func (t Table) hasRow(row int) bool {
+	for _, c := range t.Cells {
+		if c.Row == row {
+			return true
+		}
+	}
+	return false
+}
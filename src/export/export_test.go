@@ -0,0 +1,114 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"io"
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/heatmap"
+	"periodic-table/ui/periodic_table/scheme"
+	"strings"
+	"testing"
+)
+
+var (
+	hydrogen = element.Data{AtomicNumber: "1", Symbol: "H", Element: "Hydrogen", Type: "Nonmetal", Density: "0.0000899"}
+	helium   = element.Data{AtomicNumber: "2", Symbol: "He", Element: "Helium", Type: "Noble Gas"}
+	lithium  = element.Data{AtomicNumber: "3", Symbol: "Li", Element: "Lithium", Type: "Alkali Metal", Density: "0.534"}
+)
+
+// testCells is a grid of two rows of three columns, with an empty row
+// between them as above the lanthanides.
+func testCells() []grid.Cell {
+	return []grid.Cell{
+		element.CreateElement(hydrogen, false), element.CreateElement(element.Data{}, true), element.CreateElement(helium, false),
+		element.CreateElement(element.Data{}, true), element.CreateElement(element.Data{}, true), element.CreateElement(element.Data{}, true),
+		element.CreateElement(lithium, false), element.CreateElement(element.Data{}, true), element.CreateElement(element.Data{}, true),
+	}
+}
+
+func isHelium(d element.Data) bool {
+	return d.Symbol == "He"
+}
+
+func TestNew(t *testing.T) {
+	table := New(scheme.Type, testCells(), 3, isHelium, true)
+
+	if table.Rows != 3 || len(table.Cells) != 3 {
+		t.Fatalf("got %d rows and %d cells, want 3 rows and 3 cells", table.Rows, len(table.Cells))
+	}
+	he := table.Cells[1]
+	if he.Row != 0 || he.Column != 2 || !he.Highlighted || he.Fill == "" || he.Text == "" {
+		t.Errorf("helium cell = %+v", he)
+	}
+	if li := table.Cells[2]; li.Row != 2 || li.Column != 0 || li.Highlighted {
+		t.Errorf("lithium cell = %+v", li)
+	}
+	if table.hasRow(1) {
+		t.Error("row 1 has cells, want it empty")
+	}
+	if table.Legend.Title != scheme.Type.Name() || len(table.Legend.Items) != len(scheme.Type.Categories()) {
+		t.Errorf("legend = %+v", table.Legend)
+	}
+	if len(table.legendItems()) != len(table.Legend.Items) {
+		t.Error("legend has a no data item, want none as every element has a type")
+	}
+}
+
+func TestNew_heatmap(t *testing.T) {
+	density, _ := element.FieldByName("density")
+	data := []element.Data{hydrogen, helium, lithium}
+	table := New(heatmap.New(density, heatmap.Linear, data), testCells(), 3, isHelium, false)
+
+	if table.Cells[1].Fill != "" {
+		t.Errorf("helium has no density but is filled with %q", table.Cells[1].Fill)
+	}
+	if len(table.Legend.Gradient) != gradientStops || table.Legend.Low != "8.99e-05" || table.Legend.High != "0.534" {
+		t.Errorf("legend = %+v", table.Legend)
+	}
+	items := table.legendItems()
+	if len(items) != 1 || items[0].Label != "No data" {
+		t.Errorf("legend items = %+v, want only no data", items)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	table := New(scheme.Type, testCells(), 3, isHelium, false)
+	check := map[string]func(out []byte) error{
+		"svg": func(out []byte) error {
+			d := xml.NewDecoder(bytes.NewReader(out))
+			for {
+				if _, err := d.Token(); err == io.EOF {
+					return nil
+				} else if err != nil {
+					return err
+				}
+			}
+		},
+		"png": func(out []byte) error {
+			_, err := png.Decode(bytes.NewReader(out))
+			return err
+		},
+		"html": func([]byte) error { return nil },
+	}
+
+	for _, format := range Formats {
+		var b bytes.Buffer
+		if err := table.Write(&b, format); err != nil {
+			t.Errorf("Write(%s) failed: %v", format, err)
+			continue
+		}
+		if err := check[format](b.Bytes()); err != nil {
+			t.Errorf("Write(%s) wrote an invalid file: %v", format, err)
+		}
+		if format != "png" && !strings.Contains(b.String(), "Helium") {
+			t.Errorf("Write(%s) left out helium", format)
+		}
+	}
+
+	if err := table.Write(io.Discard, "pdf"); err == nil {
+		t.Error("Write(pdf) succeeded, want an error")
+	}
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+var page = template.Must(template.New("page").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { margin: {{.Margin}}px; background: {{.Palette.background}}; color: {{.Palette.text}}; font-family: {{.Font}}; }
+h1 { font-size: {{.TitleSize}}px; margin: 0 0 {{.Gap}}px; }
+.table { display: grid; grid-template-columns: repeat({{.Columns}}, {{.Cell}}px); grid-template-rows: {{.Rows}}; gap: {{.Gap}}px; }
+.cell { position: relative; box-sizing: border-box; border-radius: 4px; text-align: center; overflow: hidden; }
+.cell.no-data { border: 1px dashed {{.Palette.noData}}; }
+.cell.highlighted { outline: 3px solid {{.Palette.text}}; outline-offset: -2px; }
+.number { position: absolute; top: 2px; left: 5px; font-size: {{.NumberSize}}px; }
+.symbol { display: block; margin-top: 16px; font-size: {{.SymbolSize}}px; font-weight: bold; line-height: 1; }
+.name, .mass { display: block; font-size: {{.NameSize}}px; line-height: 1.25; white-space: nowrap; }
+.legend { margin-top: {{.Margin}}px; font-size: {{.LegendSize}}px; }
+.legend h2 { font-size: inherit; margin: 0 0 8px; }
+.legend ul { list-style: none; margin: 0; padding: 0; display: flex; flex-wrap: wrap; gap: 6px 18px; }
+.legend li { display: flex; align-items: center; gap: 6px; }
+.swatch { display: inline-block; width: {{.Swatch}}px; height: {{.Swatch}}px; }
+.swatch.no-data { box-sizing: border-box; border: 1px dashed {{.Palette.noData}}; }
+.gradient { display: flex; align-items: center; gap: 8px; margin-bottom: 8px; }
+{{- if .Legend.Gradient}}
+.bar { width: {{.Bar}}px; height: {{.Swatch}}px; background: linear-gradient(to right, {{.Gradient}}); }
+{{- end}}
+@media print { body { -webkit-print-color-adjust: exact; print-color-adjust: exact; } }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="table">
+{{- range .Cells}}
+<div class="cell{{if not .Fill}} no-data{{end}}{{if .Highlighted}} highlighted{{end}}" style="grid-row: {{inc .Row}}; grid-column: {{inc .Column}};{{if .Fill}} background: {{.Fill}}; color: {{.Text}};{{end}}" title="{{.Name}}">
+<span class="number">{{.Number}}</span><span class="symbol">{{.Symbol}}</span><span class="name">{{.Name}}</span><span class="mass">{{.Mass}}</span>
+</div>
+{{- end}}
+</div>
+<div class="legend">
+<h2>{{.Legend.Title}}</h2>
+{{- if .Legend.Gradient}}
+<div class="gradient"><span>{{.Legend.Low}}</span><span class="bar"></span><span>{{.Legend.High}}</span></div>
+{{- end}}
+<ul>
+{{- range .Items}}
+<li>{{if .Color}}<span class="swatch" style="background: {{.Color}}"></span>{{else}}<span class="swatch no-data"></span>{{end}}{{.Label}}</li>
+{{- end}}
+</ul>
+</div>
+</body>
+</html>
+`))
+
+// WriteHTML writes the table as a standalone HTML page, which prints with
+// its colors.
+func (t Table) WriteHTML(w io.Writer) error {
+	var rows []string
+	for row := 0; row < t.Rows; row++ {
+		if t.hasRow(row) {
+			rows = append(rows, fmt.Sprintf("%dpx", cellSize))
+		} else {
+			rows = append(rows, fmt.Sprintf("%dpx", spacerHeight-gap))
+		}
+	}
+
+	return page.Execute(w, struct {
+		Table
+		Palette                                               map[string]template.CSS
+		Items                                                 []Item
+		Font                                                  template.CSS
+		Rows, Gradient                                        template.CSS
+		Margin, Gap, Cell, Swatch, Bar, TitleSize, LegendSize int
+		NumberSize, SymbolSize                                int
+		NameSize                                              float64
+	}{
+		Table:      t,
+		Palette:    t.palette().css(),
+		Items:      t.legendItems(),
+		Font:       template.CSS(fontFamily),
+		Rows:       template.CSS(strings.Join(rows, " ")),
+		Gradient:   template.CSS(strings.Join(t.Legend.Gradient, ", ")),
+		Margin:     margin,
+		Gap:        gap,
+		Cell:       cellSize,
+		Swatch:     swatchSize,
+		Bar:        (t.Columns*(cellSize+gap) - gap) / 2,
+		TitleSize:  titleSize,
+		LegendSize: legendSize,
+		NumberSize: numberSize,
+		SymbolSize: symbolSize,
+		NameSize:   nameSize,
+	})
+}
+
+func (p palette) css() map[string]template.CSS {
+	return map[string]template.CSS{
+		"background": template.CSS(p.background),
+		"text":       template.CSS(p.text),
+		"noData":     template.CSS(p.noData),
+	}
+}
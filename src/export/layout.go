@@ -0,0 +1,111 @@
+package export
+
+// Sizes of the images in pixels, or in CSS pixels for HTML.
+const (
+	cellSize     = 64
+	gap          = 4
+	spacerHeight = 16
+	margin       = 24
+	titleSize    = 22
+	legendSize   = 13
+	swatchSize   = 14
+	lineHeight   = 22
+)
+
+// Font sizes of the text in a cell.
+const (
+	numberSize = 11
+	symbolSize = 24
+	nameSize   = 8.5
+	massSize   = 8.5
+)
+
+type rect struct {
+	x, y, w, h float64
+}
+
+// layout is where the parts of the table are drawn in an image.
+type layout struct {
+	width, height float64
+	titleY        float64
+	cells         []rect
+	legendY       float64
+	// items are the swatches of the legend's categories, each followed by
+	// its label.
+	items []rect
+	// bar is the gradient of a heatmap's legend, between its lowest and
+	// highest value.
+	bar rect
+}
+
+// layout places the parts of the table. measure returns the width of text
+// in the given font size.
+func (t Table) layout(measure func(text string, size float64) float64) layout {
+	var l layout
+	tableWidth := float64(t.Columns)*(cellSize+gap) - gap
+	l.width = tableWidth + 2*margin
+	l.titleY = margin + titleSize
+
+	y := float64(margin + titleSize + margin/2)
+	rowY := make([]float64, t.Rows)
+	for row := 0; row < t.Rows; row++ {
+		rowY[row] = y
+		if t.hasRow(row) {
+			y += cellSize + gap
+		} else {
+			y += spacerHeight
+		}
+	}
+	for _, c := range t.Cells {
+		l.cells = append(l.cells, rect{margin + float64(c.Column)*(cellSize+gap), rowY[c.Row], cellSize, cellSize})
+	}
+
+	y += margin / 2
+	l.legendY = y + legendSize
+	y += lineHeight
+	if len(t.Legend.Gradient) > 0 {
+		low := measure(t.Legend.Low, legendSize)
+		high := measure(t.Legend.High, legendSize)
+		barWidth := tableWidth / 2
+		l.bar = rect{margin + low + 8, y, barWidth, swatchSize}
+		if end := l.bar.x + barWidth + 8 + high; end > l.width-margin {
+			l.bar.w -= end - (l.width - margin)
+		}
+		y += lineHeight
+	}
+
+	x := float64(margin)
+	for i, item := range t.legendItems() {
+		w := swatchSize + 6 + measure(item.Label, legendSize)
+		if i > 0 && x+w > margin+tableWidth {
+			x = margin
+			y += lineHeight
+		}
+		l.items = append(l.items, rect{x, y, swatchSize, swatchSize})
+		x += w + 18
+	}
+	if len(l.items) > 0 {
+		y += lineHeight
+	}
+
+	l.height = y + margin
+	return l
+}
+
+// legendItems returns the categories of the legend, followed by the color of
+// elements without data if a heatmap leaves some out.
+func (t Table) legendItems() []Item {
+	items := t.Legend.Items
+	for _, c := range t.Cells {
+		if c.Fill == "" {
+			return append(items[:len(items):len(items)], Item{Label: "No data", Color: ""})
+		}
+	}
+	return items
+}
+
+// estimate is the width of text in a sans-serif font, without measuring
+// glyphs, used where the viewer renders the text.
+func estimate(text string, size float64) float64 {
+	return float64(len([]rune(text))) * size * 0.58
+}
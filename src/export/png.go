@@ -0,0 +1,186 @@
+package export
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/lucasb-eyer/go-colorful"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// pngScale is the number of pixels of the PNG image for every pixel of the
+// layout, so the image stays sharp when printed.
+const pngScale = 2
+
+var (
+	regular = mustParse(goregular.TTF)
+	bold    = mustParse(gobold.TTF)
+)
+
+func mustParse(ttf []byte) *opentype.Font {
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// canvas draws the layout onto an image, in layout pixels.
+type canvas struct {
+	img   *image.RGBA
+	faces map[faceKey]font.Face
+}
+
+type faceKey struct {
+	font *opentype.Font
+	size float64
+}
+
+func (c *canvas) face(f *opentype.Font, size float64) font.Face {
+	key := faceKey{f, size}
+	if face, ok := c.faces[key]; ok {
+		return face
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size * pngScale, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		panic(err)
+	}
+	c.faces[key] = face
+	return face
+}
+
+// measure returns the width of text in the regular font, in layout pixels.
+func (c *canvas) measure(text string, size float64) float64 {
+	return float64(font.MeasureString(c.face(regular, size), text)) / 64 / pngScale
+}
+
+func (c *canvas) fill(r rect, col color.Color) {
+	draw.Draw(c.img, scaled(r), image.NewUniform(col), image.Point{}, draw.Over)
+}
+
+// stroke draws the outline of r, width layout pixels wide on its inside.
+// Dashed outlines leave out every other few pixels.
+func (c *canvas) stroke(r rect, width float64, col color.Color, dashed bool) {
+	sides := []rect{
+		{r.x, r.y, r.w, width},
+		{r.x, r.y + r.h - width, r.w, width},
+		{r.x, r.y, width, r.h},
+		{r.x + r.w - width, r.y, width, r.h},
+	}
+	for i, side := range sides {
+		if !dashed {
+			c.fill(side, col)
+			continue
+		}
+		horizontal := i < 2
+		length := side.w
+		if !horizontal {
+			length = side.h
+		}
+		for at := 0.0; at < length; at += 7 {
+			dash := math.Min(4, length-at)
+			if horizontal {
+				c.fill(rect{side.x + at, side.y, dash, width}, col)
+			} else {
+				c.fill(rect{side.x, side.y + at, width, dash}, col)
+			}
+		}
+	}
+}
+
+// text draws text with its baseline at y, starting at x, centered on x or
+// ending at x for an anchor of 0, 0.5 or 1. Text wider than maxWidth, if
+// given, is drawn in a smaller size.
+func (c *canvas) text(text string, x, y float64, f *opentype.Font, size float64, col color.Color, anchor, maxWidth float64) {
+	face := c.face(f, size)
+	width := float64(font.MeasureString(face, text)) / 64 / pngScale
+	if maxWidth > 0 && width > maxWidth {
+		size *= maxWidth / width
+		face = c.face(f, size)
+		width = float64(font.MeasureString(face, text)) / 64 / pngScale
+	}
+	d := font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(col),
+		Face: face,
+		Dot:  fixed.P(int((x-width*anchor)*pngScale), int(y*pngScale)),
+	}
+	d.DrawString(text)
+}
+
+func scaled(r rect) image.Rectangle {
+	return image.Rect(int(math.Round(r.x*pngScale)), int(math.Round(r.y*pngScale)),
+		int(math.Round((r.x+r.w)*pngScale)), int(math.Round((r.y+r.h)*pngScale)))
+}
+
+// WritePNG writes the table as a PNG image, drawn at twice the size of the
+// SVG image.
+func (t Table) WritePNG(w io.Writer) error {
+	c := &canvas{faces: map[faceKey]font.Face{}}
+	l := t.layout(c.measure)
+	p := t.palette()
+
+	c.img = image.NewRGBA(scaled(rect{0, 0, l.width, l.height}))
+	c.fill(rect{0, 0, l.width, l.height}, hex(p.background))
+	c.text(t.Title, margin, l.titleY, bold, titleSize, hex(p.text), 0, 0)
+
+	for i, cell := range t.Cells {
+		r := l.cells[i]
+		text := hex(cell.Text)
+		if cell.Fill == "" {
+			text = hex(p.text)
+			c.stroke(r, 1, hex(p.noData), true)
+		} else {
+			c.fill(r, hex(cell.Fill))
+		}
+		if cell.Highlighted {
+			c.stroke(r, 3, hex(p.text), false)
+		}
+		c.text(cell.Number, r.x+5, r.y+13, regular, numberSize, text, 0, 0)
+		c.text(cell.Symbol, r.x+r.w/2, r.y+37, bold, symbolSize, text, 0.5, 0)
+		c.text(cell.Name, r.x+r.w/2, r.y+50, regular, nameSize, text, 0.5, r.w-6)
+		c.text(cell.Mass, r.x+r.w/2, r.y+60, regular, massSize, text, 0.5, r.w-6)
+	}
+
+	c.text(t.Legend.Title, margin, l.legendY, bold, legendSize, hex(p.text), 0, 0)
+	if n := len(t.Legend.Gradient); n > 0 {
+		bar := l.bar
+		for x := 0.0; x < bar.w; x += 1.0 / pngScale {
+			position := x / bar.w * float64(n-1)
+			i := int(math.Min(position, float64(n-2)))
+			from, _ := colorful.Hex(t.Legend.Gradient[i])
+			to, _ := colorful.Hex(t.Legend.Gradient[i+1])
+			c.fill(rect{bar.x + x, bar.y, 1.0 / pngScale, bar.h}, from.BlendRgb(to, position-float64(i)))
+		}
+		c.text(t.Legend.Low, bar.x-8, bar.y+11, regular, legendSize, hex(p.text), 1, 0)
+		c.text(t.Legend.High, bar.x+bar.w+8, bar.y+11, regular, legendSize, hex(p.text), 0, 0)
+	}
+	for i, item := range t.legendItems() {
+		r := l.items[i]
+		if item.Color == "" {
+			c.stroke(r, 1, hex(p.noData), true)
+		} else {
+			c.fill(r, hex(item.Color))
+		}
+		c.text(item.Label, r.x+r.w+6, r.y+11, regular, legendSize, hex(p.text), 0, 0)
+	}
+
+	return png.Encode(w, c.img)
+}
+
+// hex parses a hex color, which the theme has already checked.
+func hex(s string) color.Color {
+	c, err := colorful.Hex(s)
+	if err != nil {
+		return color.Black
+	}
+	return c
+}
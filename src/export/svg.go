@@ -0,0 +1,93 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+)
+
+const fontFamily = "Helvetica, Arial, sans-serif"
+
+// WriteSVG writes the table as a standalone SVG image.
+func (t Table) WriteSVG(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	t.svg(b, true)
+	return b.Flush()
+}
+
+// svg writes the SVG element of the table, with an XML declaration if it is
+// a file of its own rather than part of a page.
+func (t Table) svg(w io.Writer, standalone bool) {
+	l := t.layout(estimate)
+	p := t.palette()
+
+	if standalone {
+		fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?>`)
+	}
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g" font-family="%s">`+"\n",
+		l.width, l.height, l.width, l.height, fontFamily)
+	fmt.Fprintf(w, "<title>%s</title>\n", html.EscapeString(t.Title))
+	fmt.Fprintf(w, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", p.background)
+	fmt.Fprintf(w, `<text x="%d" y="%g" font-size="%d" font-weight="bold" fill="%s">%s</text>`+"\n",
+		margin, l.titleY, titleSize, p.text, html.EscapeString(t.Title))
+
+	for i, c := range t.Cells {
+		r := l.cells[i]
+		fill, text := c.Fill, c.Text
+		stroke := ""
+		if fill == "" {
+			fill, text = p.background, p.text
+			stroke = fmt.Sprintf(` stroke="%s" stroke-dasharray="4 3"`, p.noData)
+		}
+		if c.Highlighted {
+			stroke = fmt.Sprintf(` stroke="%s" stroke-width="3"`, p.text)
+		}
+		fmt.Fprintf(w, `<g><title>%s</title>`, html.EscapeString(c.Name))
+		fmt.Fprintf(w, `<rect x="%g" y="%g" width="%g" height="%g" rx="4" fill="%s"%s/>`, r.x, r.y, r.w, r.h, fill, stroke)
+		fmt.Fprintf(w, `<text x="%g" y="%g" font-size="%d" fill="%s">%s</text>`, r.x+5, r.y+13, numberSize, text, c.Number)
+		fmt.Fprintf(w, `<text x="%g" y="%g" font-size="%d" font-weight="bold" text-anchor="middle" fill="%s">%s</text>`,
+			r.x+r.w/2, r.y+37, symbolSize, text, html.EscapeString(c.Symbol))
+		fmt.Fprintf(w, `<text x="%g" y="%g" font-size="%g" text-anchor="middle" fill="%s"%s>%s</text>`,
+			r.x+r.w/2, r.y+50, nameSize, text, fitLength(c.Name, nameSize, r.w-6), html.EscapeString(c.Name))
+		fmt.Fprintf(w, `<text x="%g" y="%g" font-size="%g" text-anchor="middle" fill="%s">%s</text>`,
+			r.x+r.w/2, r.y+60, massSize, text, html.EscapeString(c.Mass))
+		fmt.Fprintln(w, "</g>")
+	}
+
+	fmt.Fprintf(w, `<text x="%d" y="%g" font-size="%d" font-weight="bold" fill="%s">%s</text>`+"\n",
+		margin, l.legendY, legendSize, p.text, html.EscapeString(t.Legend.Title))
+	if len(t.Legend.Gradient) > 0 {
+		fmt.Fprintln(w, `<defs><linearGradient id="gradient">`)
+		for i, color := range t.Legend.Gradient {
+			fmt.Fprintf(w, `<stop offset="%g" stop-color="%s"/>`+"\n", float64(i)/float64(len(t.Legend.Gradient)-1), color)
+		}
+		fmt.Fprintln(w, `</linearGradient></defs>`)
+		bar := l.bar
+		fmt.Fprintf(w, `<rect x="%g" y="%g" width="%g" height="%g" fill="url(#gradient)"/>`+"\n", bar.x, bar.y, bar.w, bar.h)
+		fmt.Fprintf(w, `<text x="%g" y="%g" font-size="%d" text-anchor="end" fill="%s">%s</text>`+"\n",
+			bar.x-8, bar.y+11, legendSize, p.text, html.EscapeString(t.Legend.Low))
+		fmt.Fprintf(w, `<text x="%g" y="%g" font-size="%d" fill="%s">%s</text>`+"\n",
+			bar.x+bar.w+8, bar.y+11, legendSize, p.text, html.EscapeString(t.Legend.High))
+	}
+	for i, item := range t.legendItems() {
+		r := l.items[i]
+		if item.Color == "" {
+			fmt.Fprintf(w, `<rect x="%g" y="%g" width="%g" height="%g" fill="none" stroke="%s" stroke-dasharray="3 2"/>`, r.x, r.y, r.w, r.h, p.noData)
+		} else {
+			fmt.Fprintf(w, `<rect x="%g" y="%g" width="%g" height="%g" fill="%s"/>`, r.x, r.y, r.w, r.h, item.Color)
+		}
+		fmt.Fprintf(w, `<text x="%g" y="%g" font-size="%d" fill="%s">%s</text>`+"\n",
+			r.x+r.w+6, r.y+11, legendSize, p.text, html.EscapeString(item.Label))
+	}
+	fmt.Fprintln(w, "</svg>")
+}
+
+// fitLength squeezes text which would be wider than width, returning the
+// attributes to do so.
+func fitLength(text string, size, width float64) string {
+	if estimate(text, size) <= width {
+		return ""
+	}
+	return fmt.Sprintf(` textLength="%g" lengthAdjust="spacingAndGlyphs"`, width)
+}
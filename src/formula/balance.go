@@ -0,0 +1,206 @@
+package formula
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Balance returns the equation with the smallest whole coefficients which
+// conserve the atoms of every element and the charge. They are solved for
+// exactly, in rationals, so the result doesn't depend on rounding.
+//
+// It fails for equations which no coefficients balance, such as
+// "H2 -> O2", and for ones which many unrelated sets of coefficients
+// balance, such as two reactions written as one.
+func (e Equation) Balance() (Equation, error) {
+	species := e.Species()
+	if err := e.checkSides(); err != nil {
+		return e, err
+	}
+
+	// Each row says an element, or the charge, is conserved: the atoms among
+	// the reactants minus those among the products are zero.
+	var rows [][]*big.Rat
+	row := map[string]int{}
+	charged := false
+	for j, s := range species {
+		sign := int64(1)
+		if j >= len(e.Reactants) {
+			sign = -1
+		}
+		for _, a := range s.Formula.Atoms {
+			i, ok := row[a.Symbol()]
+			if !ok {
+				i = len(rows)
+				row[a.Symbol()] = i
+				rows = append(rows, zeros(len(species)))
+			}
+			rows[i][j].SetInt64(sign * int64(a.Count))
+		}
+		charged = charged || s.Formula.Charge != 0
+	}
+	if charged {
+		charges := zeros(len(species))
+		for j, s := range species {
+			sign := int64(1)
+			if j >= len(e.Reactants) {
+				sign = -1
+			}
+			charges[j].SetInt64(sign * int64(s.Formula.Charge))
+		}
+		rows = append(rows, charges)
+	}
+
+	pivots := reduce(rows, len(species))
+	switch free := len(species) - len(pivots); {
+	case free == 0 && charged:
+		return e, errors.New("no coefficients conserve both the atoms and the charge, check the formulas")
+	case free == 0:
+		return e, errors.New("no coefficients conserve every element, check the formulas")
+	case free > 1:
+		return e, errors.New("the equation balances in more than one independent way, split it into separate reactions")
+	}
+
+	// The coefficient of the species without a pivot is free: set it to 1
+	// and read the others off the reduced rows.
+	x := zeros(len(species))
+	freeColumn := 0
+	for _, c := range pivots {
+		if c != freeColumn {
+			break
+		}
+		freeColumn++
+	}
+	x[freeColumn].SetInt64(1)
+	for i, c := range pivots {
+		x[c].Neg(rows[i][freeColumn])
+	}
+
+	coefficients, err := integers(x)
+	if err != nil {
+		return e, err
+	}
+	balanced := Equation{Source: e.Source}
+	for j, s := range species {
+		switch {
+		case coefficients[j] == 0:
+			return e, newError(e.Source, s.Pos, "%s takes no part in the reaction", s.Written())
+		case coefficients[j] < 0:
+			return e, newError(e.Source, s.Pos, "%s would have to be on the other side", s.Written())
+		}
+		s.Coefficient = coefficients[j]
+		if j < len(e.Reactants) {
+			balanced.Reactants = append(balanced.Reactants, s)
+		} else {
+			balanced.Products = append(balanced.Products, s)
+		}
+	}
+	return balanced, nil
+}
+
+// checkSides reports an element found on one side only, which can't be
+// balanced, pointing at the first species with it.
+func (e Equation) checkSides() error {
+	symbols := func(species []Species) map[string]bool {
+		found := map[string]bool{}
+		for _, s := range species {
+			for _, a := range s.Formula.Atoms {
+				found[a.Symbol()] = true
+			}
+		}
+		return found
+	}
+	reactants, products := symbols(e.Reactants), symbols(e.Products)
+	check := func(species []Species, other map[string]bool, side string) error {
+		for _, s := range species {
+			for _, a := range s.Formula.Atoms {
+				if !other[a.Symbol()] {
+					return newError(e.Source, s.Pos, "%s is only among the %s", a.Symbol(), side)
+				}
+			}
+		}
+		return nil
+	}
+	if err := check(e.Reactants, products, "reactants"); err != nil {
+		return err
+	}
+	return check(e.Products, reactants, "products")
+}
+
+func zeros(n int) []*big.Rat {
+	row := make([]*big.Rat, n)
+	for i := range row {
+		row[i] = new(big.Rat)
+	}
+	return row
+}
+
+// reduce brings rows to reduced row echelon form in place and returns the
+// column of the pivot of each nonzero row.
+func reduce(rows [][]*big.Rat, columns int) []int {
+	var pivots []int
+	r := 0
+	for c := 0; c < columns && r < len(rows); c++ {
+		p := -1
+		for i := r; i < len(rows); i++ {
+			if rows[i][c].Sign() != 0 {
+				p = i
+				break
+			}
+		}
+		if p < 0 {
+			continue
+		}
+		rows[r], rows[p] = rows[p], rows[r]
+
+		inverse := new(big.Rat).Inv(rows[r][c])
+		for j := c; j < columns; j++ {
+			rows[r][j].Mul(rows[r][j], inverse)
+		}
+		for i := range rows {
+			if i == r || rows[i][c].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(rows[i][c])
+			for j := c; j < columns; j++ {
+				rows[i][j].Sub(rows[i][j], new(big.Rat).Mul(factor, rows[r][j]))
+			}
+		}
+		pivots = append(pivots, c)
+		r++
+	}
+	return pivots
+}
+
+// integers scales x to the smallest whole numbers with the same ratios,
+// with as many of them positive as there can be.
+func integers(x []*big.Rat) ([]int, error) {
+	lcm := big.NewInt(1)
+	for _, v := range x {
+		d := v.Denom()
+		gcd := new(big.Int).GCD(nil, nil, lcm, d)
+		lcm.Mul(lcm, new(big.Int).Quo(d, gcd))
+	}
+
+	values := make([]*big.Int, len(x))
+	gcd := new(big.Int)
+	sign := 0
+	for i, v := range x {
+		values[i] = new(big.Int).Mul(v.Num(), new(big.Int).Quo(lcm, v.Denom()))
+		gcd.GCD(nil, nil, gcd, new(big.Int).Abs(values[i]))
+		sign += values[i].Sign()
+	}
+
+	ints := make([]int, len(x))
+	for i, v := range values {
+		v.Quo(v, gcd)
+		if sign < 0 {
+			v.Neg(v)
+		}
+		if !v.IsInt64() || v.Int64() > maxCount || v.Int64() < -maxCount {
+			return nil, errors.New("the coefficients are too large")
+		}
+		ints[i] = int(v.Int64())
+	}
+	return ints, nil
+}
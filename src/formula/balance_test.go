@@ -0,0 +1,98 @@
+package formula
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEquation_Balance(t *testing.T) {
+	tests := []struct {
+		equation string
+		want     string
+	}{
+		{equation: "Fe + O2 -> Fe2O3", want: "4Fe + 3O₂ → 2Fe₂O₃"},
+		{equation: "Fe+O2→Fe2O3", want: "4Fe + 3O₂ → 2Fe₂O₃"},
+		{equation: "C3H8 + O2 -> CO2 + H2O", want: "C₃H₈ + 5O₂ → 3CO₂ + 4H₂O"},
+		{equation: "2H2 + O2 = 2H2O", want: "2H₂ + O₂ → 2H₂O"},
+		{equation: "CuSO4·5H2O -> CuSO4 + H2O", want: "CuSO₄·5H₂O → CuSO₄ + 5H₂O"},
+		{equation: "NH4+ + OH- -> NH3 + H2O", want: "NH₄⁺ + OH⁻ → NH₃ + H₂O"},
+		{equation: "Fe^3+ + Cu -> Fe^2+ + Cu^2+", want: "2Fe³⁺ + Cu → 2Fe²⁺ + Cu²⁺"},
+		{equation: "Fe+3 + e- -> Fe+2", want: "Fe³⁺ + e⁻ → Fe²⁺"},
+		{equation: "H₂O₂ ⇌ H₂O + O₂", want: "2H₂O₂ → 2H₂O + O₂"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.equation, func(t *testing.T) {
+			e, err := ParseEquation(tt.equation, testData)
+			if err != nil {
+				t.Fatalf("ParseEquation() error = %v", err)
+			}
+			balanced, err := e.Balance()
+			if err != nil {
+				t.Fatalf("Balance() error = %v", err)
+			}
+			if got := balanced.String(); got != tt.want {
+				t.Errorf("Balance() = %v, want %v", got, tt.want)
+			}
+			for _, tally := range balanced.Tallies() {
+				if tally.Reactants != tally.Products {
+					t.Errorf("%s: %d among the reactants, %d among the products", tally.Symbol, tally.Reactants, tally.Products)
+				}
+			}
+		})
+	}
+}
+
+func TestEquation_Balance_Errors(t *testing.T) {
+	tests := []struct {
+		equation string
+		wantPos  int
+		wantMsg  string
+	}{
+		{equation: "Fe + O2", wantPos: 7, wantMsg: `expected an arrow such as "->" between the reactants and the products`},
+		{equation: "-> H2O", wantPos: 0, wantMsg: "expected the reactants before the arrow"},
+		{equation: "H2 ->", wantPos: 5, wantMsg: "expected the products after the arrow"},
+		{equation: "H2 -> H2 -> H2", wantPos: 9, wantMsg: `expected a single arrow, found "->" after "->"`},
+		{equation: "Fe -> Xy", wantPos: 6, wantMsg: `unknown element "Xy"`},
+		{equation: "H2 + -> H2", wantPos: 5, wantMsg: "expected a formula"},
+		{equation: "H2 -> O2", wantPos: 0, wantMsg: "H is only among the reactants"},
+		{equation: "H2O + H2 -> H2O", wantPos: 6, wantMsg: "H₂ takes no part in the reaction"},
+		{equation: "O2 + H2O2 -> H2O", wantPos: 0, wantMsg: "O₂ would have to be on the other side"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.equation, func(t *testing.T) {
+			e, err := ParseEquation(tt.equation, testData)
+			if err == nil {
+				_, err = e.Balance()
+			}
+			var formulaErr *Error
+			if !errors.As(err, &formulaErr) {
+				t.Fatalf("error = %v, want *Error", err)
+			}
+			if formulaErr.Pos != tt.wantPos || formulaErr.Msg != tt.wantMsg {
+				t.Errorf("error = %q at %d, want %q at %d", formulaErr.Msg, formulaErr.Pos, tt.wantMsg, tt.wantPos)
+			}
+		})
+	}
+}
+
+func TestEquation_Balance_Unsolvable(t *testing.T) {
+	tests := []struct {
+		equation string
+		want     string
+	}{
+		{equation: "Fe -> Fe^3+", want: "no coefficients conserve both the atoms and the charge"},
+		{equation: "H2 + O2 -> H2O + H2O2", want: "more than one independent way"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.equation, func(t *testing.T) {
+			e, err := ParseEquation(tt.equation, testData)
+			if err != nil {
+				t.Fatalf("ParseEquation() error = %v", err)
+			}
+			if _, err := e.Balance(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Balance() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package formula
+
+import (
+	"errors"
+	"periodic-table/ui/periodic_table/element"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// arrows separate the reactants from the products, longest first so that
+// "<=>" isn't read as "=".
+var arrows = []string{"<=>", "<->", "->", "=>", "=", "→", "⟶", "⇌", "⇄", "↔", "⟷"}
+
+// electrons are the ways of writing an electron in a half-reaction.
+var electrons = []string{"e", "e-", "e⁻", "e−"}
+
+// Equation is a chemical equation, balanced if its species have
+// coefficients.
+type Equation struct {
+	Source    string
+	Reactants []Species
+	Products  []Species
+}
+
+// Species is a formula on one side of an equation.
+type Species struct {
+	Formula Formula
+	// Electron is set for the electrons of half-reactions, whose formula has
+	// no atoms and a charge of -1.
+	Electron bool
+	// Pos is the position of the species in the equation.
+	Pos int
+	// Coefficient is the number of the species in the balanced equation, 0
+	// before it is balanced.
+	Coefficient int
+}
+
+// Written returns the species as it was typed, with subscripts and
+// superscripts.
+func (s Species) Written() string {
+	if s.Electron {
+		return "e⁻"
+	}
+	return s.Formula.Written()
+}
+
+// ParseEquation parses an equation such as "Fe + O2 -> Fe2O3", looking up
+// its element symbols among data. The sides are separated by an arrow such
+// as "->", "→" or "=", and species by a "+" between spaces or right before a
+// formula, so "Fe+3" is an ion but "Fe+O2" are two species. Coefficients
+// typed before a species are left out, as Balance finds its own. Electrons
+// are written "e-" or "e⁻".
+func ParseEquation(source string, data []element.Data) (Equation, error) {
+	src := []rune(source)
+	arrow, arrowEnd := -1, 0
+	for i := 0; i < len(src); i++ {
+		for _, a := range arrows {
+			if !hasPrefixAt(src, i, a) {
+				continue
+			}
+			if arrow >= 0 {
+				return Equation{}, newError(source, i, "expected a single arrow, found %q after %q", a, string(src[arrow:arrowEnd]))
+			}
+			arrow, arrowEnd = i, i+len([]rune(a))
+			i = arrowEnd - 1
+			break
+		}
+	}
+	if arrow < 0 {
+		return Equation{}, newError(source, len(src), "expected an arrow such as \"->\" between the reactants and the products")
+	}
+
+	e := Equation{Source: source}
+	var err error
+	if strings.TrimSpace(string(src[:arrow])) == "" {
+		return e, newError(source, arrow, "expected the reactants before the arrow")
+	}
+	if strings.TrimSpace(string(src[arrowEnd:])) == "" {
+		return e, newError(source, len(src), "expected the products after the arrow")
+	}
+	if e.Reactants, err = parseSide(source, src, 0, arrow, data); err != nil {
+		return Equation{}, err
+	}
+	if e.Products, err = parseSide(source, src, arrowEnd, len(src), data); err != nil {
+		return Equation{}, err
+	}
+	return e, nil
+}
+
+// parseSide parses the species of the equation from the position from up to
+// to.
+func parseSide(source string, src []rune, from, to int, data []element.Data) ([]Species, error) {
+	var species []Species
+	start := from
+	for i := from; i <= to; i++ {
+		if i < to && !isSpeciesSeparator(src, i, from, to) {
+			continue
+		}
+		s, err := parseSpecies(source, src, start, i, data)
+		if err != nil {
+			return nil, err
+		}
+		species = append(species, s)
+		start = i + 1
+	}
+	return species, nil
+}
+
+// isSpeciesSeparator reports whether the character at i is a "+" between
+// species rather than a charge: one between spaces or right before a
+// formula.
+func isSpeciesSeparator(src []rune, i, from, to int) bool {
+	if src[i] != '+' {
+		return false
+	}
+	if i > from && src[i-1] == ' ' && (i+1 == to || src[i+1] == ' ') {
+		return true
+	}
+	if i+1 < to {
+		r := src[i+1]
+		return unicode.IsUpper(r) || closers[r] != 0
+	}
+	return false
+}
+
+func parseSpecies(source string, src []rune, from, to int, data []element.Data) (Species, error) {
+	for from < to && src[from] == ' ' {
+		from++
+	}
+	for to > from && src[to-1] == ' ' {
+		to--
+	}
+	if from == to {
+		return Species{}, newError(source, from, "expected a formula")
+	}
+
+	// A coefficient is skipped if a formula follows it.
+	start := from
+	for start < to && isASCIIDigit(src[start]) {
+		start++
+	}
+	for start < to && src[start] == ' ' {
+		start++
+	}
+	if start == to {
+		start = from
+	}
+
+	text := string(src[start:to])
+	for _, e := range electrons {
+		if text == e {
+			return Species{Formula: Formula{Source: text, Charge: -1, written: "e⁻"}, Electron: true, Pos: start}, nil
+		}
+	}
+
+	f, err := Parse(text, data)
+	var formulaErr *Error
+	if errors.As(err, &formulaErr) {
+		return Species{}, newError(source, start+formulaErr.Pos, "%s", formulaErr.Msg)
+	} else if err != nil {
+		return Species{}, err
+	}
+	return Species{Formula: f, Pos: start}, nil
+}
+
+func hasPrefixAt(src []rune, i int, prefix string) bool {
+	p := []rune(prefix)
+	if i+len(p) > len(src) {
+		return false
+	}
+	for j, r := range p {
+		if src[i+j] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the equation with its coefficients, subscripts and
+// superscripts, such as "4Fe + 3O₂ → 2Fe₂O₃".
+func (e Equation) String() string {
+	return side(e.Reactants) + " → " + side(e.Products)
+}
+
+func side(species []Species) string {
+	var terms []string
+	for _, s := range species {
+		term := s.Written()
+		if s.Coefficient > 1 {
+			term = strconv.Itoa(s.Coefficient) + term
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(terms, " + ")
+}
+
+// Tally is the number of atoms of an element on each side of an equation,
+// counting coefficients.
+type Tally struct {
+	// Symbol is the symbol of the element, or "charge" for the total charge.
+	Symbol    string
+	Reactants int
+	Products  int
+}
+
+// Tallies counts the atoms of every element on both sides, in the order they
+// first appear, followed by the charge if any species is charged.
+func (e Equation) Tallies() []Tally {
+	var tallies []Tally
+	index := map[string]int{}
+	charged := false
+	add := func(species []Species, products bool) {
+		for _, s := range species {
+			n := s.Coefficient
+			if n == 0 {
+				n = 1
+			}
+			charged = charged || s.Formula.Charge != 0
+			for _, a := range s.Formula.Atoms {
+				i, ok := index[a.Symbol()]
+				if !ok {
+					i = len(tallies)
+					index[a.Symbol()] = i
+					tallies = append(tallies, Tally{Symbol: a.Symbol()})
+				}
+				if products {
+					tallies[i].Products += n * a.Count
+				} else {
+					tallies[i].Reactants += n * a.Count
+				}
+			}
+		}
+	}
+	add(e.Reactants, false)
+	add(e.Products, true)
+
+	if charged {
+		t := Tally{Symbol: "charge"}
+		for _, s := range e.Reactants {
+			t.Reactants += max(s.Coefficient, 1) * s.Formula.Charge
+		}
+		for _, s := range e.Products {
+			t.Products += max(s.Coefficient, 1) * s.Formula.Charge
+		}
+		tallies = append(tallies, t)
+	}
+	return tallies
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,9 @@
+package formula
+
+import "periodic-table/src/syntax"
+
+// Error is returned when a formula cannot be parsed, at the offending
+// character of the formula.
+type Error = syntax.Error
+
+var newError = syntax.Errorf
@@ -0,0 +1,225 @@
+// Package formula parses chemical formulas such as "CuSO4·5H2O", "[Cu(NH3)4]^2+"
+// or "¹³CH4" and computes their molar mass and percent composition.
+//
+// Elements are counted by subscripts, written as digits or as Unicode
+// subscripts. Groups are nested in parentheses, brackets or braces, and the
+// parts of a hydrate or adduct are joined by "·", "•", "." or "*", each with
+// an optional leading coefficient. A charge ends the formula, written in
+// superscripts ("SO₄²⁻"), after a caret ("SO4^2-"), as a trailing sign with
+// an optional number ("NH4+", "Fe+3") or after a space ("SO4 2-"). Digits
+// right before a trailing sign are a subscript, so "Fe3+" is Fe₃⁺; write
+// "Fe^3+" for the iron(III) ion.
+//
+// Isotopes are labeled with their mass number in superscripts ("¹³C"), after
+// a caret ("^13C") or in brackets ("[13C]"). D and T stand for ²H and ³H.
+//
+// Equations of formulas, such as "Fe + O2 -> Fe2O3", are read by
+// ParseEquation and balanced by Equation.Balance.
+package formula
+
+import (
+	"math"
+	"periodic-table/ui/periodic_table/element"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// electronMass is the molar mass of the electron in g/mol, added or removed
+// for the charge of an ion.
+const electronMass = 0.000548579909
+
+// Formula is a parsed formula, with its atoms counted over all groups and
+// parts.
+type Formula struct {
+	Source string
+	// Atoms are the elements of the formula in Hill order: carbon, then
+	// hydrogen, then the others alphabetically, or all alphabetically if
+	// there is no carbon. Isotopes of an element follow the element.
+	Atoms  []Atom
+	Charge int
+	// written is the source written with subscripts.
+	written string
+}
+
+// Atom is an element, or one of its isotopes, and its number in a formula.
+type Atom struct {
+	Element element.Data
+	// MassNumber labels an isotope, it is 0 for the natural mix.
+	MassNumber int
+	Count      int
+}
+
+// Symbol returns the symbol of the element, preceded by the mass number in
+// superscripts for isotopes.
+func (a Atom) Symbol() string {
+	symbol := strings.TrimSpace(a.Element.Symbol)
+	if a.MassNumber == 0 {
+		return symbol
+	}
+	return Superscript(strconv.Itoa(a.MassNumber)) + symbol
+}
+
+// Mass returns the molar mass of the atom in g/mol: the standard atomic
+// weight, or the mass of the isotope. Isotopes missing from the table of
+// common ones are taken to weigh their mass number, which is within 0.1 of
+// their true mass.
+func (a Atom) Mass() float64 {
+	if a.MassNumber == 0 {
+		mass, _ := strconv.ParseFloat(a.Element.AtomicMass, 64)
+		return mass
+	}
+	if mass, ok := isotopeMasses[strconv.Itoa(a.MassNumber)+strings.TrimSpace(a.Element.Symbol)]; ok {
+		return mass
+	}
+	return float64(a.MassNumber)
+}
+
+// MolarMass returns the mass of a mole of the formula in g/mol.
+func (f Formula) MolarMass() float64 {
+	var mass float64
+	for _, a := range f.Atoms {
+		mass += a.Mass() * float64(a.Count)
+	}
+	return mass - float64(f.Charge)*electronMass
+}
+
+// Share is the part an element has in the mass of a formula.
+type Share struct {
+	Atom
+	// Mass is the mass of all atoms of the element in a mole of the formula,
+	// in grams.
+	Mass    float64
+	Percent float64
+}
+
+// Composition returns the share of every element of the formula in its mass,
+// in the order of Atoms.
+func (f Formula) Composition() []Share {
+	total := f.MolarMass() + float64(f.Charge)*electronMass
+	var shares []Share
+	for _, a := range f.Atoms {
+		s := Share{Atom: a, Mass: a.Mass() * float64(a.Count)}
+		if total > 0 {
+			s.Percent = 100 * s.Mass / total
+		}
+		shares = append(shares, s)
+	}
+	return shares
+}
+
+// String returns the formula in Hill notation with subscripts, followed by
+// its charge in superscripts, such as "CuH₁₀O₉S" for "CuSO4·5H2O".
+func (f Formula) String() string {
+	var b strings.Builder
+	for _, a := range f.Atoms {
+		b.WriteString(a.Symbol())
+		if a.Count > 1 {
+			b.WriteString(Subscript(strconv.Itoa(a.Count)))
+		}
+	}
+	b.WriteString(ChargeString(f.Charge))
+	return b.String()
+}
+
+// Written returns the formula as it was typed, with subscripts, "·" between
+// the parts of a hydrate and the charge in superscripts, such as "CuSO₄·5H₂O"
+// for "CuSO4.5H2O" or "SO₄²⁻" for "SO4^2-".
+func (f Formula) Written() string {
+	return f.written
+}
+
+// ChargeString returns a charge in superscripts, such as "²⁻", or an empty
+// string for no charge.
+func ChargeString(charge int) string {
+	if charge == 0 {
+		return ""
+	}
+	sign := "⁺"
+	if charge < 0 {
+		sign = "⁻"
+	}
+	n := int(math.Abs(float64(charge)))
+	if n == 1 {
+		return sign
+	}
+	return Superscript(strconv.Itoa(n)) + sign
+}
+
+// hillLess orders atoms in Hill order.
+func hillLess(atoms []Atom, hasCarbon bool) func(i, j int) bool {
+	rank := func(a Atom) int {
+		if !hasCarbon {
+			return 2
+		}
+		switch strings.TrimSpace(a.Element.Symbol) {
+		case "C":
+			return 0
+		case "H":
+			return 1
+		}
+		return 2
+	}
+	return func(i, j int) bool {
+		a, b := atoms[i], atoms[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		if sa, sb := strings.TrimSpace(a.Element.Symbol), strings.TrimSpace(b.Element.Symbol); sa != sb {
+			return sa < sb
+		}
+		return a.MassNumber < b.MassNumber
+	}
+}
+
+func sortHill(atoms []Atom) {
+	hasCarbon := false
+	for _, a := range atoms {
+		if strings.TrimSpace(a.Element.Symbol) == "C" {
+			hasCarbon = true
+		}
+	}
+	sort.SliceStable(atoms, hillLess(atoms, hasCarbon))
+}
+
+const (
+	subscripts   = "₀₁₂₃₄₅₆₇₈₉"
+	superscripts = "⁰¹²³⁴⁵⁶⁷⁸⁹"
+)
+
+// Subscript writes the digits of s as Unicode subscripts.
+func Subscript(s string) string {
+	return mapDigits(s, []rune(subscripts))
+}
+
+// Superscript writes the digits of s as Unicode superscripts.
+func Superscript(s string) string {
+	return mapDigits(s, []rune(superscripts))
+}
+
+func mapDigits(s string, digits []rune) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return digits[r-'0']
+		}
+		return r
+	}, s)
+}
+
+// digitValue returns the value of an ASCII, subscript or superscript digit.
+func digitValue(r rune) (int, bool) {
+	if r >= '0' && r <= '9' {
+		return int(r - '0'), true
+	}
+	for i, d := range []rune(subscripts) {
+		if r == d {
+			return i, true
+		}
+	}
+	for i, d := range []rune(superscripts) {
+		if r == d {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,173 @@
+package formula
+
+import (
+	"errors"
+	"math"
+	"periodic-table/ui/periodic_table/element"
+	"testing"
+)
+
+var testData = []element.Data{
+	{AtomicNumber: "1", Element: "Hydrogen", Symbol: "H", AtomicMass: "1.007"},
+	{AtomicNumber: "2", Element: "Helium", Symbol: "He", AtomicMass: "4.002"},
+	{AtomicNumber: "6", Element: "Carbon", Symbol: "C", AtomicMass: "12.011"},
+	{AtomicNumber: "7", Element: "Nitrogen", Symbol: "N", AtomicMass: "14.007"},
+	{AtomicNumber: "8", Element: "Oxygen", Symbol: "O", AtomicMass: "15.999"},
+	{AtomicNumber: "11", Element: "Sodium", Symbol: "Na", AtomicMass: "22.99"},
+	{AtomicNumber: "16", Element: "Sulfur", Symbol: "S", AtomicMass: "32.065"},
+	{AtomicNumber: "17", Element: "Chlorine", Symbol: "Cl", AtomicMass: "35.453"},
+	{AtomicNumber: "26", Element: "Iron", Symbol: "Fe ", AtomicMass: "55.845"},
+	{AtomicNumber: "29", Element: "Copper", Symbol: "Cu", AtomicMass: "63.546"},
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    string
+	}{
+		{formula: "CuSO4·5H2O", want: "CuH₁₀O₉S"},
+		{formula: "CuSO4.5H2O", want: "CuH₁₀O₉S"},
+		{formula: "CuSO₄ * 5 H₂O", want: "CuH₁₀O₉S"},
+		{formula: "[Cu(NH3)4]SO4", want: "CuH₁₂N₄O₄S"},
+		{formula: "{Cu[(NH3)2]2}", want: "CuH₁₂N₄"},
+		{formula: "CH3COOH", want: "C₂H₄O₂"},
+		{formula: "2H2O", want: "H₄O₂"},
+		{formula: "NaCl", want: "ClNa"},
+		{formula: "Fe(CN)6^4-", want: "C₆FeN₆⁴⁻"},
+		{formula: "SO4^2-", want: "O₄S²⁻"},
+		{formula: "SO₄²⁻", want: "O₄S²⁻"},
+		{formula: "SO4 2-", want: "O₄S²⁻"},
+		{formula: "SO4--", want: "O₄S²⁻"},
+		{formula: "SO4−2", want: "O₄S²⁻"},
+		{formula: "NH4+", want: "H₄N⁺"},
+		{formula: "Fe+3", want: "Fe³⁺"},
+		{formula: "Fe3+", want: "Fe₃⁺"},
+		{formula: "¹³CH4", want: "¹³CH₄"},
+		{formula: "^13CH4", want: "¹³CH₄"},
+		{formula: "[13C]H4", want: "¹³CH₄"},
+		{formula: "CH3[13C]H3", want: "C¹³CH₆"},
+		{formula: "D2O", want: "²H₂O"},
+		{formula: "HTO", want: "H³HO"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			f, err := Parse(tt.formula, testData)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := f.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormula_MolarMass(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    float64
+	}{
+		{formula: "CuSO4·5H2O", want: 249.672},
+		{formula: "H2O", want: 18.013},
+		{formula: "D2O", want: 20.027},
+		{formula: "SO4^2-", want: 96.062},
+		{formula: "¹³CH4", want: 17.031},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			f, err := Parse(tt.formula, testData)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := f.MolarMass(); math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("MolarMass() = %.4f, want %.3f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormula_Composition(t *testing.T) {
+	f, err := Parse("NaCl", testData)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	shares := f.Composition()
+	if len(shares) != 2 {
+		t.Fatalf("Composition() has %d shares, want 2", len(shares))
+	}
+	want := map[string]float64{"Cl": 60.663, "Na": 39.337}
+	var total float64
+	for _, s := range shares {
+		if math.Abs(s.Percent-want[s.Symbol()]) > 0.001 {
+			t.Errorf("%s is %.3f%%, want %.3f%%", s.Symbol(), s.Percent, want[s.Symbol()])
+		}
+		total += s.Percent
+	}
+	if math.Abs(total-100) > 1e-9 {
+		t.Errorf("percentages add up to %v, want 100", total)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		formula string
+		wantPos int
+		wantMsg string
+	}{
+		{formula: "", wantPos: 0, wantMsg: "empty formula"},
+		{formula: "CuXy2", wantPos: 2, wantMsg: `unknown element "Xy"`},
+		{formula: "h2o", wantPos: 0, wantMsg: `element symbols start with a capital letter, not 'h'`},
+		{formula: "Cu(OH2", wantPos: 2, wantMsg: `'(' is never closed`},
+		{formula: "(H2O]", wantPos: 4, wantMsg: `expected ')' but found ']'`},
+		{formula: "H2O)", wantPos: 3, wantMsg: `unexpected ')' without an opening bracket`},
+		{formula: "Cu()", wantPos: 2, wantMsg: "empty group"},
+		{formula: "H0", wantPos: 1, wantMsg: "number must be at least 1"},
+		{formula: "^1He", wantPos: 0, wantMsg: "mass number 1 is less than the atomic number of He, 2"},
+		{formula: "[13C", wantPos: 4, wantMsg: `expected "]" after the isotope 13C`},
+		{formula: "H2O·", wantPos: 4, wantMsg: `expected a formula after '·'`},
+		{formula: "SO4^2-x", wantPos: 6, wantMsg: `unexpected 'x' after the charge`},
+		{formula: "Fe+-", wantPos: 3, wantMsg: "charge mixes + and -"},
+		{formula: "SO4 2", wantPos: 5, wantMsg: "expected + or - for the charge"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			_, err := Parse(tt.formula, testData)
+			var formulaErr *Error
+			if !errors.As(err, &formulaErr) {
+				t.Fatalf("Parse() error = %v, want *Error", err)
+			}
+			if formulaErr.Pos != tt.wantPos || formulaErr.Msg != tt.wantMsg {
+				t.Errorf("Parse() error = %q at %d, want %q at %d", formulaErr.Msg, formulaErr.Pos, tt.wantMsg, tt.wantPos)
+			}
+		})
+	}
+}
+
+func TestFormula_Written(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    string
+	}{
+		{formula: "CuSO4.5H2O", want: "CuSO₄·5H₂O"},
+		{formula: "CuSO₄ * 5 H₂O", want: "CuSO₄·5H₂O"},
+		{formula: "Cu(OH)2", want: "Cu(OH)₂"},
+		{formula: "SO4^2-", want: "SO₄²⁻"},
+		{formula: "SO4 2-", want: "SO₄²⁻"},
+		{formula: "NH4+", want: "NH₄⁺"},
+		{formula: "Fe+3", want: "Fe³⁺"},
+		{formula: "^13CH4", want: "¹³CH₄"},
+		{formula: "[13C]H4", want: "¹³CH₄"},
+		{formula: "[Cu(NH3)4]^2+", want: "[Cu(NH₃)₄]²⁺"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			f, err := Parse(tt.formula, testData)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := f.Written(); got != tt.want {
+				t.Errorf("Written() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package formula
+
+// isotopeMasses are the masses in g/mol of isotopes commonly used as labels,
+// keyed by mass number and symbol, from the AME2020 atomic mass evaluation.
+var isotopeMasses = map[string]float64{
+	"1H":   1.00782503,
+	"2H":   2.01410178,
+	"3H":   3.01604928,
+	"12C":  12,
+	"13C":  13.00335484,
+	"14C":  14.00324199,
+	"14N":  14.00307401,
+	"15N":  15.00010890,
+	"16O":  15.99491462,
+	"17O":  16.99913176,
+	"18O":  17.99915961,
+	"19F":  18.99840316,
+	"31P":  30.97376200,
+	"32P":  31.97390764,
+	"32S":  31.97207117,
+	"34S":  33.96786701,
+	"35S":  34.96903232,
+	"35Cl": 34.96885268,
+	"37Cl": 36.96590260,
+	"79Br": 78.91833760,
+	"81Br": 80.91628970,
+	"125I": 124.90462940,
+	"131I": 130.90612630,
+	"60Co": 59.93381630,
+	"99Tc": 98.90624970,
+	"235U": 235.04392810,
+	"238U": 238.05078700,
+}
@@ -0,0 +1,95 @@
+package formula
+
+import "strings"
+
+// oxidationStates are the common oxidation states of the elements, the most
+// common first. Noble gases and the heaviest elements have none. Oxygen also
+// lists -1 for peroxides and hydrogen -1 for hydrides.
+var oxidationStates = map[string][]int{
+	"H": {1, -1}, "Li": {1}, "Be": {2}, "B": {3}, "C": {4, -4, 2}, "N": {-3, 3, 5},
+	"O": {-2, -1}, "F": {-1}, "Na": {1}, "Mg": {2}, "Al": {3}, "Si": {4, -4},
+	"P": {5, 3, -3}, "S": {-2, 6, 4, 2}, "Cl": {-1, 1, 3, 5, 7}, "K": {1}, "Ca": {2},
+	"Sc": {3}, "Ti": {4, 3}, "V": {5, 4}, "Cr": {3, 6}, "Mn": {2, 4, 7}, "Fe": {3, 2},
+	"Co": {2, 3}, "Ni": {2}, "Cu": {2, 1}, "Zn": {2}, "Ga": {3}, "Ge": {4, 2, -4},
+	"As": {3, 5, -3}, "Se": {-2, 4, 6, 2}, "Br": {-1, 1, 3, 5}, "Kr": {2},
+	"Rb": {1}, "Sr": {2}, "Y": {3}, "Zr": {4}, "Nb": {5}, "Mo": {6, 4}, "Tc": {7, 4},
+	"Ru": {3, 4}, "Rh": {3}, "Pd": {2, 4}, "Ag": {1}, "Cd": {2}, "In": {3},
+	"Sn": {4, 2, -4}, "Sb": {3, 5, -3}, "Te": {-2, 4, 6, 2}, "I": {-1, 1, 3, 5, 7},
+	"Xe": {2, 4, 6}, "Cs": {1}, "Ba": {2}, "La": {3}, "Ce": {3, 4}, "Pr": {3},
+	"Nd": {3}, "Pm": {3}, "Sm": {3}, "Eu": {3, 2}, "Gd": {3}, "Tb": {3}, "Dy": {3},
+	"Ho": {3}, "Er": {3}, "Tm": {3}, "Yb": {3}, "Lu": {3}, "Hf": {4}, "Ta": {5},
+	"W": {6, 4}, "Re": {4}, "Os": {4}, "Ir": {3, 4}, "Pt": {2, 4}, "Au": {3, 1},
+	"Hg": {2, 1}, "Tl": {1, 3}, "Pb": {2, 4}, "Bi": {3}, "Po": {2, 4, -2},
+	"At": {-1, 1}, "Rn": {2}, "Fr": {1}, "Ra": {2}, "Ac": {3}, "Th": {4}, "Pa": {5},
+	"U": {6, 4}, "Np": {5}, "Pu": {4}, "Am": {3}, "Cm": {3}, "Bk": {3}, "Cf": {3},
+	"Es": {3}, "Fm": {3}, "Md": {3}, "No": {2}, "Lr": {3}, "Rf": {4}, "Db": {5},
+	"Sg": {6}, "Bh": {7}, "Hs": {8},
+}
+
+// OxidationStates returns the oxidation state of every atom of the formula,
+// in the order of Atoms, chosen among the common states of each element so
+// that they add up to the charge. The most common states are preferred. It
+// reports false if no choice of common states does, which often means a
+// formula is wrong but also happens for unusual compounds. Atoms of a formula
+// of a single element, such as O₂, are all in state 0.
+func (f Formula) OxidationStates() ([]int, bool) {
+	states := make([]int, len(f.Atoms))
+	if len(f.Atoms) == 0 {
+		return states, f.Charge == 0
+	}
+	if single(f.Atoms) {
+		total := 0
+		for _, a := range f.Atoms {
+			total += a.Count
+		}
+		state := f.Charge / total
+		if f.Charge%total != 0 || (state != 0 && !contains(oxidationStates[symbolOf(f.Atoms[0])], state)) {
+			return nil, false
+		}
+		for i := range states {
+			states[i] = state
+		}
+		return states, true
+	}
+
+	var search func(i, sum int) bool
+	search = func(i, sum int) bool {
+		if i == len(f.Atoms) {
+			return sum == f.Charge
+		}
+		for _, state := range oxidationStates[symbolOf(f.Atoms[i])] {
+			states[i] = state
+			if search(i+1, sum+state*f.Atoms[i].Count) {
+				return true
+			}
+		}
+		return false
+	}
+	if !search(0, 0) {
+		return nil, false
+	}
+	return states, true
+}
+
+// single reports whether the atoms are all of one element.
+func single(atoms []Atom) bool {
+	for _, a := range atoms {
+		if symbolOf(a) != symbolOf(atoms[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+func symbolOf(a Atom) string {
+	return strings.TrimSpace(a.Element.Symbol)
+}
+
+func contains(states []int, state int) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
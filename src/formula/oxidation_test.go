@@ -0,0 +1,41 @@
+package formula
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormula_OxidationStates(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    []int
+		wantOK  bool
+	}{
+		{formula: "Fe2O3", want: []int{3, -2}, wantOK: true},
+		{formula: "FeO", want: []int{2, -2}, wantOK: true},
+		{formula: "NaCl", want: []int{-1, 1}, wantOK: true},
+		{formula: "H2O2", want: []int{1, -1}, wantOK: true},
+		{formula: "SO4^2-", want: []int{-2, 6}, wantOK: true},
+		{formula: "NH4+", want: []int{1, -3}, wantOK: true},
+		{formula: "O2", want: []int{0}, wantOK: true},
+		{formula: "Fe^3+", want: []int{3}, wantOK: true},
+		{formula: "NaCl2", wantOK: false},
+		{formula: "HeO", wantOK: false},
+		{formula: "Cu^5+", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			f, err := Parse(tt.formula, testData)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got, ok := f.OxidationStates()
+			if ok != tt.wantOK {
+				t.Fatalf("OxidationStates() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OxidationStates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,458 @@
+package formula
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxCount bounds counts and coefficients, far above any real formula, so
+// their products can't overflow.
+const maxCount = 1000000
+
+// Parse parses source, looking up its element symbols among data.
+func Parse(source string, data []element.Data) (Formula, error) {
+	elements := map[string]element.Data{}
+	for _, d := range data {
+		elements[strings.TrimSpace(d.Symbol)] = d
+	}
+
+	p := parser{source: source, src: []rune(source), elements: elements, rewrites: map[int]string{}}
+	f, err := p.formula()
+	if err != nil {
+		return Formula{}, err
+	}
+	sortHill(f.Atoms)
+	f.written = p.written(f.Charge)
+	return f, nil
+}
+
+type parser struct {
+	source   string
+	src      []rune
+	pos      int
+	elements map[string]element.Data
+	// rewrites replace characters when writing the formula back, such as the
+	// digits of counts by subscripts, and chargeFrom is where the charge
+	// starts.
+	rewrites   map[int]string
+	chargeFrom int
+}
+
+func (p *parser) formula() (Formula, error) {
+	f := Formula{Source: p.source}
+	p.skipSpaces()
+	if p.atEnd() {
+		return f, newError(p.source, p.pos, "empty formula")
+	}
+
+	for {
+		atoms, err := p.part()
+		if err != nil {
+			return f, err
+		}
+		f.Atoms = merge(f.Atoms, atoms, 1)
+
+		p.chargeFrom = p.pos
+		p.skipSpaces()
+		if p.atEnd() {
+			return f, nil
+		}
+		if isSeparator(p.peek()) {
+			p.pos++
+			p.skipSpaces()
+			if p.atEnd() {
+				return f, newError(p.source, p.pos, "expected a formula after %q", p.src[p.pos-1])
+			}
+			continue
+		}
+
+		if f.Charge, err = p.charge(); err != nil {
+			return f, err
+		}
+		if !p.atEnd() {
+			return f, newError(p.source, p.pos, "unexpected %q after the charge", p.peek())
+		}
+		return f, nil
+	}
+}
+
+// part parses a formula between hydrate separators, with an optional
+// leading coefficient.
+func (p *parser) part() ([]Atom, error) {
+	coefficient := 1
+	if isASCIIDigit(p.peek()) {
+		var err error
+		if coefficient, err = p.number(); err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+	}
+
+	start := p.pos
+	atoms, err := p.sequence(0)
+	if err != nil {
+		return nil, err
+	}
+	if len(atoms) == 0 {
+		if p.atEnd() {
+			return nil, newError(p.source, start, "expected an element")
+		}
+		return nil, newError(p.source, start, "unexpected %q", p.peek())
+	}
+	return merge(nil, atoms, coefficient), nil
+}
+
+var closers = map[rune]rune{'(': ')', '[': ']', '{': '}'}
+
+// sequence parses elements and groups up to closer, or up to the first
+// character which can't start either if closer is 0.
+func (p *parser) sequence(closer rune) ([]Atom, error) {
+	var atoms []Atom
+	for !p.atEnd() {
+		r := p.peek()
+		switch {
+		case r == closer:
+			return atoms, nil
+		case r == ')' || r == ']' || r == '}':
+			if closer == 0 {
+				return nil, newError(p.source, p.pos, "unexpected %q without an opening bracket", r)
+			}
+			return nil, newError(p.source, p.pos, "expected %q but found %q", closer, r)
+		case r == '[' && isASCIIDigit(p.peekAt(1)):
+			a, err := p.atom()
+			if err != nil {
+				return nil, err
+			}
+			count, err := p.count()
+			if err != nil {
+				return nil, err
+			}
+			atoms = merge(atoms, []Atom{a}, count)
+		case closers[r] != 0:
+			open := p.pos
+			p.pos++
+			inner, err := p.sequence(closers[r])
+			if err != nil {
+				return nil, err
+			}
+			if p.atEnd() {
+				return nil, newError(p.source, open, "%q is never closed", r)
+			}
+			if len(inner) == 0 {
+				return nil, newError(p.source, open, "empty group")
+			}
+			p.pos++
+			count, err := p.count()
+			if err != nil {
+				return nil, err
+			}
+			atoms = merge(atoms, inner, count)
+		case unicode.IsUpper(r) || p.isIsotopeLabel():
+			a, err := p.atom()
+			if err != nil {
+				return nil, err
+			}
+			count, err := p.count()
+			if err != nil {
+				return nil, err
+			}
+			atoms = merge(atoms, []Atom{a}, count)
+		case unicode.IsLower(r):
+			return nil, newError(p.source, p.pos, "element symbols start with a capital letter, not %q", r)
+		default:
+			if closer != 0 {
+				return nil, newError(p.source, p.pos, "expected %q but found %q", closer, r)
+			}
+			return atoms, nil
+		}
+	}
+	return atoms, nil
+}
+
+// isIsotopeLabel reports whether a mass number in superscripts or after a
+// caret starts at the position, rather than a charge.
+func (p *parser) isIsotopeLabel() bool {
+	i, isDigit := p.pos, isSuperscriptDigit
+	if p.peek() == '^' {
+		i, isDigit = i+1, isASCIIDigit
+	}
+	digits := i
+	for i < len(p.src) && isDigit(p.src[i]) {
+		i++
+	}
+	return i > digits && i < len(p.src) && unicode.IsUpper(p.src[i])
+}
+
+// atom parses an element symbol with an optional isotope label.
+func (p *parser) atom() (Atom, error) {
+	start := p.pos
+	massNumber := 0
+	bracketed := false
+	switch {
+	case p.peek() == '^':
+		p.rewrites[p.pos] = ""
+		p.pos++
+		n, err := p.number()
+		if err != nil {
+			return Atom{}, err
+		}
+		p.rewrite(start+1, Superscript)
+		massNumber = n
+	case p.peek() == '[':
+		p.rewrites[p.pos] = ""
+		p.pos++
+		bracketed = true
+		n, err := p.number()
+		if err != nil {
+			return Atom{}, err
+		}
+		p.rewrite(start+1, Superscript)
+		massNumber = n
+	case isSuperscriptDigit(p.peek()):
+		n, err := p.digits(isSuperscriptDigit)
+		if err != nil {
+			return Atom{}, err
+		}
+		massNumber = n
+	}
+
+	symbolStart := p.pos
+	if !unicode.IsUpper(p.peek()) {
+		return Atom{}, newError(p.source, p.pos, "expected an element symbol after the mass number")
+	}
+	symbol := string(p.peek())
+	p.pos++
+	if unicode.IsLower(p.peek()) {
+		symbol += string(p.peek())
+		p.pos++
+	}
+	if bracketed {
+		if p.peek() != ']' {
+			return Atom{}, newError(p.source, p.pos, "expected \"]\" after the isotope %d%s", massNumber, symbol)
+		}
+		p.rewrites[p.pos] = ""
+		p.pos++
+	}
+
+	// D and T are the heavy isotopes of hydrogen.
+	if hydrogen, ok := p.elements["H"]; ok && (symbol == "D" || symbol == "T") {
+		if massNumber != 0 {
+			return Atom{}, newError(p.source, start, "%s already is an isotope", symbol)
+		}
+		massNumber = 2
+		if symbol == "T" {
+			massNumber = 3
+		}
+		return Atom{Element: hydrogen, MassNumber: massNumber, Count: 1}, nil
+	}
+
+	d, ok := p.elements[symbol]
+	if !ok {
+		return Atom{}, newError(p.source, symbolStart, "unknown element %q", symbol)
+	}
+	if z, _ := strconv.Atoi(d.AtomicNumber); massNumber != 0 && massNumber < z {
+		return Atom{}, newError(p.source, start, "mass number %d is less than the atomic number of %s, %d", massNumber, symbol, z)
+	}
+	return Atom{Element: d, MassNumber: massNumber, Count: 1}, nil
+}
+
+// count parses an optional subscript, in ASCII or Unicode subscript digits.
+func (p *parser) count() (int, error) {
+	start := p.pos
+	var (
+		n   int
+		err error
+	)
+	switch {
+	case isASCIIDigit(p.peek()):
+		n, err = p.number()
+	case isSubscriptDigit(p.peek()):
+		n, err = p.digits(isSubscriptDigit)
+	default:
+		return 1, nil
+	}
+	p.rewrite(start, Subscript)
+	return n, err
+}
+
+// rewrite writes the characters from start up to the position with write.
+func (p *parser) rewrite(start int, write func(string) string) {
+	for i := start; i < p.pos; i++ {
+		p.rewrites[i] = write(string(p.src[i]))
+	}
+}
+
+func (p *parser) hasRewrite(i int) bool {
+	_, ok := p.rewrites[i]
+	return ok
+}
+
+// written returns the formula as typed with subscripts, without spaces, with
+// "·" between parts and with the charge in superscripts.
+func (p *parser) written(charge int) string {
+	end := len(p.src)
+	if charge != 0 {
+		end = p.chargeFrom
+	}
+	var b strings.Builder
+	for i, r := range p.src[:end] {
+		switch {
+		case r == ' ':
+		case p.hasRewrite(i):
+			b.WriteString(p.rewrites[i])
+		case isSeparator(r):
+			b.WriteRune('·')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteString(ChargeString(charge))
+	return b.String()
+}
+
+// number parses ASCII digits, which must not be 0.
+func (p *parser) number() (int, error) {
+	return p.digits(isASCIIDigit)
+}
+
+func (p *parser) digits(isDigit func(rune) bool) (int, error) {
+	start := p.pos
+	n := 0
+	for !p.atEnd() && isDigit(p.peek()) {
+		d, _ := digitValue(p.peek())
+		n = n*10 + d
+		if n > maxCount {
+			return 0, newError(p.source, start, "number is too large")
+		}
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, newError(p.source, p.pos, "expected a number")
+	}
+	if n == 0 {
+		return 0, newError(p.source, start, "number must be at least 1")
+	}
+	return n, nil
+}
+
+// charge parses the charge at the end of a formula: an optional caret, then
+// a number followed by a sign, a sign followed by a number, or one or more
+// signs.
+func (p *parser) charge() (int, error) {
+	start := p.pos
+	if p.peek() == '^' {
+		p.pos++
+	}
+
+	isChargeDigit := func(r rune) bool { return isASCIIDigit(r) || isSuperscriptDigit(r) }
+	n := 0
+	if isChargeDigit(p.peek()) {
+		var err error
+		if n, err = p.digits(isChargeDigit); err != nil {
+			return 0, err
+		}
+	}
+
+	signStart := p.pos
+	sign, signs := 0, 0
+	for !p.atEnd() && chargeSign(p.peek()) != 0 {
+		if sign != 0 && chargeSign(p.peek()) != sign {
+			return 0, newError(p.source, p.pos, "charge mixes + and -")
+		}
+		sign = chargeSign(p.peek())
+		signs++
+		p.pos++
+	}
+	if signs == 0 {
+		if p.atEnd() {
+			return 0, newError(p.source, p.pos, "expected + or - for the charge")
+		}
+		return 0, newError(p.source, p.pos, "unexpected %q", p.peek())
+	}
+
+	if n == 0 && signs == 1 && isChargeDigit(p.peek()) {
+		var err error
+		if n, err = p.digits(isChargeDigit); err != nil {
+			return 0, err
+		}
+	}
+	if n != 0 && signs > 1 {
+		return 0, newError(p.source, signStart, "charge has both a number and several signs")
+	}
+	if n == 0 {
+		n = signs
+	}
+	if p.pos == start {
+		return 0, newError(p.source, start, "expected a charge")
+	}
+	return sign * n, nil
+}
+
+func (p *parser) skipSpaces() {
+	for !p.atEnd() && p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) atEnd() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek() rune {
+	return p.peekAt(0)
+}
+
+func (p *parser) peekAt(offset int) rune {
+	if p.pos+offset >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos+offset]
+}
+
+// merge adds factor times the atoms of add to atoms.
+func merge(atoms []Atom, add []Atom, factor int) []Atom {
+	for _, a := range add {
+		found := false
+		for i := range atoms {
+			if atoms[i].Element.AtomicNumber == a.Element.AtomicNumber && atoms[i].MassNumber == a.MassNumber {
+				atoms[i].Count += a.Count * factor
+				found = true
+				break
+			}
+		}
+		if !found {
+			a.Count *= factor
+			atoms = append(atoms, a)
+		}
+	}
+	return atoms
+}
+
+func isSeparator(r rune) bool {
+	return strings.ContainsRune("·•⋅.*", r)
+}
+
+// chargeSign returns 1 for plus signs, -1 for minus signs and 0 otherwise.
+func chargeSign(r rune) int {
+	switch r {
+	case '+', '⁺':
+		return 1
+	case '-', '⁻', '−':
+		return -1
+	}
+	return 0
+}
+
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isSubscriptDigit(r rune) bool {
+	return strings.ContainsRune(subscripts, r)
+}
+
+func isSuperscriptDigit(r rune) bool {
+	return strings.ContainsRune(superscripts, r)
+}
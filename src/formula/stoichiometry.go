@@ -0,0 +1,215 @@
+package formula
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MolarVolume is the volume of a mole of an ideal gas at STP, 0 °C and
+// 1 atm, in liters, as used in most textbooks.
+const MolarVolume = 22.414
+
+// units convert the units of quantities to grams, moles or liters.
+var units = map[string]struct {
+	base   string
+	factor float64
+}{
+	"g":    {"g", 1},
+	"mg":   {"g", 1e-3},
+	"kg":   {"g", 1e3},
+	"mol":  {"mol", 1},
+	"mmol": {"mol", 1e-3},
+	"L":    {"L", 1},
+	"l":    {"L", 1},
+	"mL":   {"L", 1e-3},
+	"ml":   {"L", 1e-3},
+}
+
+// Quantity is an amount of a species in grams, moles or liters of gas at
+// STP.
+type Quantity struct {
+	Value float64
+	// Unit is "g", "mol" or "L".
+	Unit string
+}
+
+// String returns the quantity with its unit, such as "10 g".
+func (q Quantity) String() string {
+	return strconv.FormatFloat(q.Value, 'g', -1, 64) + " " + q.Unit
+}
+
+// Moles converts the quantity of s to moles.
+func (q Quantity) Moles(s Species) float64 {
+	switch q.Unit {
+	case "g":
+		return q.Value / s.Formula.MolarMass()
+	case "L":
+		return q.Value / MolarVolume
+	}
+	return q.Value
+}
+
+// Index returns the index of the species named name, counting the reactants
+// and then the products. Species are named by their formula as typed, with
+// or without subscripts, or in Hill notation.
+func (e Equation) Index(name string) (int, bool) {
+	name = strings.ReplaceAll(name, " ", "")
+	for i, s := range e.Species() {
+		if name == strings.ReplaceAll(s.Formula.Source, " ", "") || name == s.Written() || name == s.Formula.String() {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Species returns the reactants followed by the products.
+func (e Equation) Species() []Species {
+	return append(append([]Species{}, e.Reactants...), e.Products...)
+}
+
+// ParseQuantities parses the quantities of species of e, such as
+// "Fe=10g, O2=5 L", keyed by the index of the species. The units are g, mg,
+// kg, mol, mmol, L and mL.
+func ParseQuantities(source string, e Equation) (map[int]Quantity, error) {
+	src := []rune(source)
+	quantities := map[int]Quantity{}
+	i := 0
+	skip := func() {
+		for i < len(src) && (src[i] == ' ' || src[i] == ',') {
+			i++
+		}
+	}
+	for skip(); i < len(src); skip() {
+		start := i
+		for i < len(src) && src[i] != '=' {
+			i++
+		}
+		if i == len(src) {
+			return nil, newError(source, start, "expected a species followed by = and its amount, such as Fe=10g")
+		}
+		name := strings.TrimSpace(string(src[start:i]))
+		index, ok := e.Index(name)
+		if !ok {
+			return nil, newError(source, start, "%q is not in the equation", name)
+		}
+		if _, ok := quantities[index]; ok {
+			return nil, newError(source, start, "%s is given twice", name)
+		}
+
+		i++
+		for i < len(src) && src[i] == ' ' {
+			i++
+		}
+		numberStart := i
+		for i < len(src) && isNumberRune(src, i, numberStart) {
+			i++
+		}
+		value, err := strconv.ParseFloat(string(src[numberStart:i]), 64)
+		if err != nil || value <= 0 || math.IsInf(value, 0) {
+			return nil, newError(source, numberStart, "expected a positive amount")
+		}
+		for i < len(src) && src[i] == ' ' {
+			i++
+		}
+		unitStart := i
+		for i < len(src) && unicode.IsLetter(src[i]) {
+			i++
+		}
+		unit, ok := units[string(src[unitStart:i])]
+		if !ok {
+			return nil, newError(source, unitStart, "expected a unit: g, mg, kg, mol, mmol, L or mL")
+		}
+		quantities[index] = Quantity{Value: value * unit.factor, Unit: unit.base}
+	}
+	return quantities, nil
+}
+
+// isNumberRune reports whether the character at i continues a number which
+// starts at start, possibly with an exponent.
+func isNumberRune(src []rune, i, start int) bool {
+	r := src[i]
+	switch {
+	case isASCIIDigit(r) || r == '.':
+		return true
+	case r == 'e' || r == 'E':
+		return i > start && i+1 < len(src) && (isASCIIDigit(src[i+1]) || src[i+1] == '-' || src[i+1] == '+')
+	case r == '-' || r == '+':
+		return i > start && (src[i-1] == 'e' || src[i-1] == 'E')
+	}
+	return false
+}
+
+// Stoichiometry is the outcome of a balanced reaction run until its limiting
+// reagent is used up.
+type Stoichiometry struct {
+	Equation  Equation
+	Reactants []Reagent
+	Products  []Reagent
+	// Limiting is the index of the limiting reagent among the reactants.
+	Limiting int
+}
+
+// Reagent is the amount of a species before and after the reaction, in
+// moles.
+type Reagent struct {
+	Species
+	// Given is the quantity given for the species, nil if none was. For a
+	// product it is the actual yield.
+	Given *Quantity
+	// Moles is the amount which reacts for a reactant, which is the amount
+	// needed if none was given, and the theoretical yield for a product.
+	Moles float64
+	// Left is the excess of a reactant left over.
+	Left float64
+	// Percent is the actual yield of a product against its theoretical
+	// yield, 0 if no actual yield was given.
+	Percent float64
+}
+
+// Grams converts moles of the reagent to grams.
+func (r Reagent) Grams(moles float64) float64 {
+	return moles * r.Formula.MolarMass()
+}
+
+// Solve finds the limiting reagent among the reactants with a quantity, and
+// the amounts which react and form with it. quantities are keyed by the
+// index of the species, as returned by Index. The equation must be balanced.
+func (e Equation) Solve(quantities map[int]Quantity) (Stoichiometry, error) {
+	st := Stoichiometry{Equation: e, Limiting: -1}
+	extent := math.Inf(1)
+	for i, s := range e.Reactants {
+		q, ok := quantities[i]
+		if !ok {
+			continue
+		}
+		if n := q.Moles(s) / float64(s.Coefficient); n < extent {
+			extent, st.Limiting = n, i
+		}
+	}
+	if st.Limiting < 0 {
+		return st, errors.New("give the amount of at least one reactant")
+	}
+
+	for i, s := range e.Reactants {
+		r := Reagent{Species: s, Moles: extent * float64(s.Coefficient)}
+		if q, ok := quantities[i]; ok {
+			r.Given = &q
+			r.Left = math.Max(q.Moles(s)-r.Moles, 0)
+		}
+		st.Reactants = append(st.Reactants, r)
+	}
+	for i, s := range e.Products {
+		r := Reagent{Species: s, Moles: extent * float64(s.Coefficient)}
+		if q, ok := quantities[len(e.Reactants)+i]; ok {
+			r.Given = &q
+			if r.Moles > 0 {
+				r.Percent = 100 * q.Moles(s) / r.Moles
+			}
+		}
+		st.Products = append(st.Products, r)
+	}
+	return st, nil
+}
@@ -0,0 +1,72 @@
+package formula
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEquation_Solve(t *testing.T) {
+	e, err := ParseEquation("Fe + O2 -> Fe2O3", testData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, err = e.Balance(); err != nil {
+		t.Fatal(err)
+	}
+	quantities, err := ParseQuantities("Fe=10g, O2 = 5 L Fe₂O₃=1.2e1 g", e)
+	if err != nil {
+		t.Fatalf("ParseQuantities() error = %v", err)
+	}
+	st, err := e.Solve(quantities)
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+
+	if st.Limiting != 0 {
+		t.Errorf("Limiting = %d, want 0 (Fe)", st.Limiting)
+	}
+	near := func(name string, got, want float64) {
+		t.Helper()
+		if math.Abs(got-want) > 1e-3*math.Abs(want) {
+			t.Errorf("%s = %.5f, want %.5f", name, got, want)
+		}
+	}
+	near("Fe reacted", st.Reactants[0].Moles, 10/55.845)
+	near("Fe left", st.Reactants[0].Left, 0)
+	near("O2 reacted", st.Reactants[1].Moles, 10/55.845*3/4)
+	near("O2 left", st.Reactants[1].Left, 5/MolarVolume-10/55.845*3/4)
+	near("Fe2O3 yield", st.Products[0].Grams(st.Products[0].Moles), 14.2975)
+	near("Fe2O3 percent yield", st.Products[0].Percent, 83.93)
+}
+
+func TestParseQuantities_Errors(t *testing.T) {
+	e, err := ParseEquation("Fe + O2 -> Fe2O3", testData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		quantities string
+		wantPos    int
+		wantMsg    string
+	}{
+		{quantities: "Fe", wantPos: 0, wantMsg: "expected a species followed by = and its amount, such as Fe=10g"},
+		{quantities: "Fe=1g, Cu=2g", wantPos: 7, wantMsg: `"Cu" is not in the equation`},
+		{quantities: "Fe=1g Fe=2g", wantPos: 6, wantMsg: "Fe is given twice"},
+		{quantities: "Fe=-1g", wantPos: 3, wantMsg: "expected a positive amount"},
+		{quantities: "Fe=10", wantPos: 5, wantMsg: "expected a unit: g, mg, kg, mol, mmol, L or mL"},
+		{quantities: "Fe=10 lb", wantPos: 6, wantMsg: "expected a unit: g, mg, kg, mol, mmol, L or mL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.quantities, func(t *testing.T) {
+			_, err := ParseQuantities(tt.quantities, e)
+			var formulaErr *Error
+			if !errors.As(err, &formulaErr) {
+				t.Fatalf("ParseQuantities() error = %v, want *Error", err)
+			}
+			if formulaErr.Pos != tt.wantPos || formulaErr.Msg != tt.wantMsg {
+				t.Errorf("ParseQuantities() error = %q at %d, want %q at %d", formulaErr.Msg, formulaErr.Pos, tt.wantMsg, tt.wantPos)
+			}
+		})
+	}
+}
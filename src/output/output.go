@@ -0,0 +1,180 @@
+// Package output writes element data in machine readable formats for the
+// command line. Fields are keyed by their stable names and missing values are
+// written as null, or left empty in CSV.
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"periodic-table/ui/periodic_table/element"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Record holds the values of some fields of one element, keeping the order
+// of the fields when encoded.
+type Record struct {
+	Fields []element.Field
+	Values []interface{}
+}
+
+// NewRecord returns the values of fields for d.
+func NewRecord(fields []element.Field, d element.Data) Record {
+	r := Record{Fields: fields}
+	for _, f := range fields {
+		r.Values = append(r.Values, f.Value(d))
+	}
+	return r
+}
+
+// Records returns a record of fields for every element of data.
+func Records(fields []element.Field, data []element.Data) []Record {
+	var records []Record
+	for _, d := range data {
+		records = append(records, NewRecord(fields, d))
+	}
+	return records
+}
+
+// Map returns the values keyed by field name, as used by templates.
+func (r Record) Map() map[string]interface{} {
+	values := map[string]interface{}{}
+	for i, f := range r.Fields {
+		values[f.Name] = r.Values[i]
+	}
+	return values
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range r.Fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, _ := json.Marshal(f.Name)
+		value, err := json.Marshal(r.Values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func (r Record) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for i, f := range r.Fields {
+		var value yaml.Node
+		if err := value.Encode(r.Values[i]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: f.Name}, &value)
+	}
+	return node, nil
+}
+
+// WriteJSON writes records as an indented JSON array.
+func WriteJSON(w io.Writer, records []Record) error {
+	if records == nil {
+		records = []Record{}
+	}
+	out, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", out)
+	return err
+}
+
+// WriteNDJSON writes every record as a JSON object on a line of its own.
+func WriteNDJSON(w io.Writer, records []Record) error {
+	enc := json.NewEncoder(w)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteYAML writes records as a YAML list.
+func WriteYAML(w io.Writer, records []Record) error {
+	if records == nil {
+		records = []Record{}
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(records); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// WriteCSV writes a header of field names and a row for every record.
+func WriteCSV(w io.Writer, fields []element.Field, records []Record) error {
+	cw := csv.NewWriter(w)
+	var header []string
+	for _, f := range fields {
+		header = append(header, f.Name)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range records {
+		var row []string
+		for _, value := range r.Values {
+			row = append(row, Format(value))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTable writes a header of field names and a row for every record,
+// aligned in columns. Missing values are shown as a dash.
+func WriteTable(w io.Writer, fields []element.Field, records []Record) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	var header []string
+	for _, f := range fields {
+		header = append(header, f.Name)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, r := range records {
+		var row []string
+		for _, value := range r.Values {
+			text := Format(value)
+			if text == "" {
+				text = "—"
+			}
+			row = append(row, text)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// Format returns a value of a record as text, empty if it is missing.
+func Format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bytes"
+	"periodic-table/ui/periodic_table/element"
+	"testing"
+)
+
+var data = []element.Data{
+	{Symbol: "Fe", AtomicMass: "55.845", Group: "8", Metal: "yes"},
+	{Symbol: "Ce", AtomicMass: "140.116", Discoverer: "Berzelius"},
+}
+
+func fields(names ...string) []element.Field {
+	var fields []element.Field
+	for _, name := range names {
+		f, _ := element.FieldByName(name)
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func TestWrite(t *testing.T) {
+	f := fields("symbol", "atomic_mass", "group", "metal", "discoverer")
+	records := Records(f, data)
+
+	tests := []struct {
+		name  string
+		write func(b *bytes.Buffer) error
+		want  string
+	}{
+		{"json", func(b *bytes.Buffer) error { return WriteJSON(b, records) }, `[
+  {
+    "symbol": "Fe",
+    "atomic_mass": 55.845,
+    "group": 8,
+    "metal": true,
+    "discoverer": null
+  },
+  {
+    "symbol": "Ce",
+    "atomic_mass": 140.116,
+    "group": null,
+    "metal": false,
+    "discoverer": "Berzelius"
+  }
+]
+`},
+		{"empty json", func(b *bytes.Buffer) error { return WriteJSON(b, nil) }, "[]\n"},
+		{"yaml", func(b *bytes.Buffer) error { return WriteYAML(b, records) }, `- symbol: Fe
+  atomic_mass: 55.845
+  group: 8
+  metal: true
+  discoverer: null
+- symbol: Ce
+  atomic_mass: 140.116
+  group: null
+  metal: false
+  discoverer: Berzelius
+`},
+		{"ndjson", func(b *bytes.Buffer) error { return WriteNDJSON(b, records) }, `{"symbol":"Fe","atomic_mass":55.845,"group":8,"metal":true,"discoverer":null}
+{"symbol":"Ce","atomic_mass":140.116,"group":null,"metal":false,"discoverer":"Berzelius"}
+`},
+		{"table", func(b *bytes.Buffer) error { return WriteTable(b, f, records) }, `symbol  atomic_mass  group  metal  discoverer
+Fe      55.845       8      true   —
+Ce      140.116      —      false  Berzelius
+`},
+		{"csv", func(b *bytes.Buffer) error { return WriteCSV(b, f, records) }, `symbol,atomic_mass,group,metal,discoverer
+Fe,55.845,8,true,
+Ce,140.116,,false,Berzelius
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.write(&b); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package query
+
+import "periodic-table/src/syntax"
+
+// Error is returned when a query cannot be parsed, at the offending
+// character of the query.
+type Error = syntax.Error
+
+var newError = syntax.Errorf
@@ -0,0 +1,113 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	eof tokenKind = iota
+	word
+	number
+	str
+	operator
+	lparen
+	rparen
+	comma
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == eof {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// is reports whether the token is the given keyword or operator.
+func (t token) is(text string) bool {
+	return (t.kind == word || t.kind == operator) && strings.EqualFold(t.text, text)
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "=", "<", ">", "~"}
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, token{kind: lparen, text: "(", pos: start})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: rparen, text: ")", pos: start})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: comma, text: ",", pos: start})
+			i++
+		case r == '"' || r == '\'':
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			if i == len(runes) {
+				return nil, newError(source, start, "unterminated string")
+			}
+			i++
+			text := string(runes[start:i])
+			tokens = append(tokens, token{kind: str, text: text, value: text[1 : len(text)-1], pos: start})
+		case unicode.IsDigit(r) || (r == '-' || r == '.') && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			i++
+			for i < len(runes) && isNumberRune(runes[i], runes[i-1]) {
+				i++
+			}
+			text := string(runes[start:i])
+			tokens = append(tokens, token{kind: number, text: text, value: text, pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			text := string(runes[start:i])
+			tokens = append(tokens, token{kind: word, text: text, value: text, pos: start})
+		default:
+			op := matchOperator(string(runes[i:]))
+			if op == "" {
+				return nil, newError(source, start, "unexpected character %q", r)
+			}
+			i += len(op)
+			tokens = append(tokens, token{kind: operator, text: op, pos: start})
+		}
+	}
+
+	return append(tokens, token{kind: eof, pos: len(runes)}), nil
+}
+
+func isNumberRune(r rune, previous rune) bool {
+	return unicode.IsDigit(r) || r == '.' || r == 'e' || r == 'E' ||
+		(r == '-' || r == '+') && (previous == 'e' || previous == 'E')
+}
+
+func matchOperator(text string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(text, op) {
+			return op
+		}
+	}
+	return ""
+}
@@ -0,0 +1,182 @@
+package query
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+)
+
+type parser struct {
+	source string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != eof {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return newError(p.source, t.pos, format, args...)
+}
+
+// or = and { "or" and }
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = or{left, right}
+	}
+	return left, nil
+}
+
+// and = unary { "and" unary }
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = and{left, right}
+	}
+	return left, nil
+}
+
+// unary = "not" unary | "(" or ")" | comparison
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	switch {
+	case t.is("not"):
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return not{operand}, nil
+	case t.kind == lparen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != rparen {
+			return nil, p.errorf(closing, "expected \")\" to close \"(\" at position %d, found %s", t.pos+1, closing)
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+// comparison = property [ operator value | [ "not" ] "in" "(" value { "," value } ")" ]
+func (p *parser) parseComparison() (node, error) {
+	t := p.next()
+	if t.kind != word || t.is("and") || t.is("or") || t.is("in") {
+		return nil, p.errorf(t, "expected a property name, found %s", t)
+	}
+
+	field, ok := element.FieldByName(t.text)
+	if !ok {
+		return nil, p.errorf(t, "%s", unknownField(t.text))
+	}
+
+	op := p.peek()
+	switch {
+	case op.kind == operator:
+		p.next()
+		value, err := p.parseValue(field, op)
+		if err != nil {
+			return nil, err
+		}
+		return comparison{field: field, op: op.text, value: value}, nil
+	case op.is("in"):
+		p.next()
+		return p.parseIn(field)
+	case op.is("not") && p.tokens[p.pos+1].is("in"):
+		p.next()
+		p.next()
+		in, err := p.parseIn(field)
+		return not{in}, err
+	}
+
+	return isSet{field}, nil
+}
+
+func (p *parser) parseIn(field element.Field) (node, error) {
+	if t := p.next(); t.kind != lparen {
+		return nil, p.errorf(t, "expected \"(\" after \"in\", found %s", t)
+	}
+
+	eq := token{kind: operator, text: "="}
+	var result node
+	for {
+		value, err := p.parseValue(field, eq)
+		if err != nil {
+			return nil, err
+		}
+
+		var c node = comparison{field: field, op: "=", value: value}
+		if result == nil {
+			result = c
+		} else {
+			result = or{result, c}
+		}
+
+		t := p.next()
+		if t.kind == rparen {
+			return result, nil
+		}
+		if t.kind != comma {
+			return nil, p.errorf(t, "expected \",\" or \")\" in list, found %s", t)
+		}
+	}
+}
+
+func (p *parser) parseValue(field element.Field, op token) (literal, error) {
+	t := p.next()
+	if t.kind != word && t.kind != number && t.kind != str {
+		return literal{}, p.errorf(t, "expected a value after %s, found %s", op, t)
+	}
+
+	value := newLiteral(t)
+	switch {
+	case op.text == "~" && field.Kind == element.Flag:
+		return literal{}, p.errorf(op, "%s does not work on yes or no properties such as %q", op, field.Name)
+	case op.text == "~":
+		return value, nil
+	case isOrdering(op.text) && (field.Kind != element.Number || !value.numeric):
+		if field.Kind != element.Number {
+			return literal{}, p.errorf(op, "%s only works on numeric properties, %q is not numeric", op, field.Name)
+		}
+		return literal{}, p.errorf(t, "%s needs a number, found %s", op, t)
+	case field.Kind == element.Flag:
+		if _, ok := flagValues[strings.ToLower(t.value)]; !ok {
+			return literal{}, p.errorf(t, "%q is yes or no, found %s", field.Name, t)
+		}
+	}
+	return value, nil
+}
+
+func isOrdering(op string) bool {
+	return op == "<" || op == "<=" || op == ">" || op == ">="
+}
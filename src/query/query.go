@@ -0,0 +1,214 @@
+// Package query implements a small expression language for filtering
+// elements by their properties, for example
+//
+//	density > 5 and phase = solid and not radioactive
+//	group in (1, 2) or type ~ "metal"
+package query
+
+import (
+	"fmt"
+	"periodic-table/ui/periodic_table/element"
+	"strconv"
+	"strings"
+)
+
+// Query is a parsed filter expression.
+type Query struct {
+	source string
+	root   node
+}
+
+// Parse parses source into a Query. Parse errors are of type *Error.
+func Parse(source string) (*Query, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{source: source, tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != eof {
+		return nil, p.errorf(t, "unexpected %s, expected \"and\" or \"or\"", t)
+	}
+
+	return &Query{source: source, root: root}, nil
+}
+
+// Match reports whether d satisfies the query.
+func (q *Query) Match(d element.Data) bool {
+	return q.root.eval(d)
+}
+
+func (q *Query) String() string {
+	return q.source
+}
+
+// Filter returns the elements which satisfy the query, in their original
+// order.
+func (q *Query) Filter(data []element.Data) []element.Data {
+	var matches []element.Data
+	for _, d := range data {
+		if q.Match(d) {
+			matches = append(matches, d)
+		}
+	}
+	return matches
+}
+
+type node interface {
+	eval(d element.Data) bool
+}
+
+type and struct{ left, right node }
+type or struct{ left, right node }
+type not struct{ operand node }
+
+func (n and) eval(d element.Data) bool { return n.left.eval(d) && n.right.eval(d) }
+func (n or) eval(d element.Data) bool  { return n.left.eval(d) || n.right.eval(d) }
+func (n not) eval(d element.Data) bool { return !n.operand.eval(d) }
+
+// isSet matches when a property has a value, e.g. "radioactive".
+type isSet struct{ field element.Field }
+
+func (n isSet) eval(d element.Data) bool { return n.field.IsSet(d) }
+
+type comparison struct {
+	field element.Field
+	op    string
+	value literal
+}
+
+type literal struct {
+	text    string
+	number  float64
+	numeric bool
+}
+
+func (n comparison) eval(d element.Data) bool {
+	if n.op == "~" {
+		return strings.Contains(strings.ToLower(n.field.Get(d)), strings.ToLower(n.value.text))
+	}
+
+	switch n.field.Kind {
+	case element.Number:
+		if n.value.numeric {
+			value, ok := n.field.Float(d)
+			if !ok {
+				return false
+			}
+			return compareNumbers(value, n.op, n.value.number)
+		}
+	case element.Flag:
+		set := n.field.IsSet(d)
+		want := isTrue(n.value.text)
+		if n.op == "!=" {
+			return set != want
+		}
+		return set == want
+	}
+
+	value := n.field.Get(d)
+	if n.op == "!=" {
+		return value != "" && !strings.EqualFold(value, n.value.text)
+	}
+	return strings.EqualFold(value, n.value.text)
+}
+
+func compareNumbers(a float64, op string, b float64) bool {
+	switch op {
+	case "=", "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+var flagValues = map[string]bool{
+	"yes":   true,
+	"true":  true,
+	"1":     true,
+	"no":    false,
+	"false": false,
+	"0":     false,
+}
+
+func isTrue(text string) bool {
+	return flagValues[strings.ToLower(text)]
+}
+
+func newLiteral(t token) literal {
+	value, err := strconv.ParseFloat(t.value, 64)
+	return literal{text: t.value, number: value, numeric: err == nil}
+}
+
+func fieldNames() string {
+	var names []string
+	for _, f := range element.Fields {
+		names = append(names, f.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// suggestField returns the field name closest to name, if any is close enough
+// to be a likely typo.
+func suggestField(name string) string {
+	best, bestDistance := "", 3
+	for _, f := range element.Fields {
+		if d := distance(strings.ToLower(name), f.Name); d < bestDistance {
+			best, bestDistance = f.Name, d
+		}
+	}
+	return best
+}
+
+// distance is the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func unknownField(name string) string {
+	msg := fmt.Sprintf("unknown property %q", name)
+	if suggestion := suggestField(name); suggestion != "" {
+		return msg + fmt.Sprintf(", did you mean %q?", suggestion)
+	}
+	return msg + " (known properties: " + fieldNames() + ")"
+}
@@ -0,0 +1,105 @@
+package query
+
+import (
+	"errors"
+	"periodic-table/ui/periodic_table/element"
+	"testing"
+)
+
+var testData = []element.Data{
+	{AtomicNumber: "1", Element: "Hydrogen", Symbol: "H", Group: "1", Period: "1", Phase: "gas", Type: "Nonmetal", Nonmetal: "yes", Density: "8.99E-05"},
+	{AtomicNumber: "11", Element: "Sodium", Symbol: "Na", Group: "1", Period: "3", Phase: "solid", Type: "Alkali Metal", Metal: "yes", Density: "0.971"},
+	{AtomicNumber: "20", Element: "Calcium", Symbol: "Ca", Group: "2", Period: "4", Phase: "solid", Type: "Alkaline Earth Metal", Metal: "yes", Density: "1.54"},
+	{AtomicNumber: "26", Element: "Iron", Symbol: "Fe", Group: "8", Period: "4", Phase: "solid", Type: "Transition Metal", Metal: "yes", Density: "7.87"},
+	{AtomicNumber: "61", Element: "Promethium", Symbol: "Pm", Period: "6", Phase: "artificial", Type: "Lanthanide", Radioactive: "yes", Metal: "yes", Density: "7.26"},
+	{AtomicNumber: "92", Element: "Uranium", Symbol: "U", Period: "7", Phase: "solid", Type: "Actinide", Radioactive: "yes", Metal: "yes", Density: "19.1"},
+}
+
+func symbols(data []element.Data) string {
+	var s string
+	for i, d := range data {
+		if i > 0 {
+			s += ","
+		}
+		s += d.Symbol
+	}
+	return s
+}
+
+func TestQuery_Filter(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "density > 5 and phase = solid and not radioactive", want: "Fe"},
+		{query: `group in (1,2) or type ~ "metal"`, want: "H,Na,Ca,Fe"},
+		{query: `group in (1, 2) and type ~ 'alkal'`, want: "Na,Ca"},
+		{query: "group not in (1, 2)", want: "Fe,Pm,U"},
+		{query: "radioactive", want: "Pm,U"},
+		{query: "radioactive = no and metal = yes", want: "Na,Ca,Fe"},
+		{query: "not (period <= 4 or density >= 10)", want: "Pm"},
+		{query: "density < 1e-3", want: "H"},
+		{query: "SYMBOL = fe OR Element = 'sodium'", want: "Na,Fe"},
+		{query: "group != 1", want: "Ca,Fe"},
+		{query: "block = f", want: "Pm,U"},
+		{query: "atomic_number ~ 2", want: "Ca,Fe,U"},
+		{query: "density ~ 7.", want: "Fe,Pm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := symbols(q.Filter(testData)); got != tt.want {
+				t.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_FilterTemperatureInKelvin(t *testing.T) {
+	element.SetTemperatureUnit(element.Celsius)
+	defer element.SetTemperatureUnit(element.Kelvin)
+
+	data := []element.Data{{Symbol: "H", MeltingPoint: "14.175"}, {Symbol: "Fe", MeltingPoint: "1811"}}
+	q, err := Parse("melting_point > 300")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := symbols(q.Filter(data)); got != "Fe" {
+		t.Errorf("Filter() = %v, want Fe", got)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantPos int
+		wantMsg string
+	}{
+		{query: "densty > 5", wantPos: 0, wantMsg: `unknown property "densty", did you mean "density"?`},
+		{query: "density > solid", wantPos: 10, wantMsg: `">" needs a number, found "solid"`},
+		{query: "phase > 5", wantPos: 6, wantMsg: `">" only works on numeric properties, "phase" is not numeric`},
+		{query: "radioactive ~ ye", wantPos: 12, wantMsg: `"~" does not work on yes or no properties such as "radioactive"`},
+		{query: "radioactive = maybe", wantPos: 14, wantMsg: `"radioactive" is yes or no, found "maybe"`},
+		{query: "(group = 1", wantPos: 10, wantMsg: `expected ")" to close "(" at position 1, found end of query`},
+		{query: "group in (1 2)", wantPos: 12, wantMsg: `expected "," or ")" in list, found "2"`},
+		{query: "type = \"metal", wantPos: 7, wantMsg: "unterminated string"},
+		{query: "group = 1 group = 2", wantPos: 10, wantMsg: `unexpected "group", expected "and" or "or"`},
+		{query: "density >", wantPos: 9, wantMsg: `expected a value after ">", found end of query`},
+		{query: "group = 1 and", wantPos: 13, wantMsg: "expected a property name, found end of query"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			var queryErr *Error
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("Parse() error = %v, want *Error", err)
+			}
+			if queryErr.Pos != tt.wantPos || queryErr.Msg != tt.wantMsg {
+				t.Errorf("Parse() error = %q at %d, want %q at %d", queryErr.Msg, queryErr.Pos, tt.wantMsg, tt.wantPos)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Package syntax reports errors in one line expressions typed by people,
+// such as filter queries and chemical formulas, at the offending character.
+package syntax
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is returned when an expression cannot be parsed. Pos is the position
+// of the offending character in Source, counted in runes from zero.
+type Error struct {
+	Source string
+	Pos    int
+	Msg    string
+}
+
+// Errorf returns an error at pos in source with a message formatted as by
+// fmt.Sprintf.
+func Errorf(source string, pos int, format string, args ...interface{}) *Error {
+	return &Error{Source: source, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+// Caret returns the expression with a marker under the offending position.
+func (e *Error) Caret() string {
+	return e.Source + "\n" + strings.Repeat(" ", e.Pos) + "^"
+}
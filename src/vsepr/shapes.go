@@ -0,0 +1,172 @@
+package vsepr
+
+// electronGeometries name the arrangement of 2 to 7 electron domains.
+var electronGeometries = map[int]string{
+	2: "linear",
+	3: "trigonal planar",
+	4: "tetrahedral",
+	5: "trigonal bipyramidal",
+	6: "octahedral",
+	7: "pentagonal bipyramidal",
+}
+
+// hybridizations are the hybrid orbitals of the central atom for 2 to 7
+// electron domains, as taught with valence bond theory.
+var hybridizations = map[int]string{
+	2: "sp",
+	3: "sp²",
+	4: "sp³",
+	5: "sp³d",
+	6: "sp³d²",
+	7: "sp³d³",
+}
+
+func shape(bonds, lonePairs int, molecular, angles string, sketch ...string) Shape {
+	return Shape{
+		Bonds:             bonds,
+		LonePairs:         lonePairs,
+		ElectronGeometry:  electronGeometries[bonds+lonePairs],
+		MolecularGeometry: molecular,
+		BondAngles:        angles,
+		Hybridization:     hybridizations[bonds+lonePairs],
+		sketch:            sketch,
+	}
+}
+
+// shapes are the shapes of two or more bonds, by their number of bonds and
+// lone pairs.
+var shapes = []Shape{
+	shape(2, 0, "linear", "180°",
+		"1  ── A  ── 2 ",
+	),
+	shape(3, 0, "trigonal planar", "120°",
+		"     1 ",
+		"     │",
+		"     A ",
+		"    ╱ ╲",
+		"  2     3 ",
+	),
+	shape(2, 1, "bent", "<120°",
+		"     ··",
+		"     A ",
+		"    ╱ ╲",
+		"  1     2 ",
+	),
+	shape(4, 0, "tetrahedral", "109.5°",
+		"     1 ",
+		"     │",
+		"     A ",
+		"    ╱┊ ╲",
+		"  2  3   4 ",
+	),
+	shape(3, 1, "trigonal pyramidal", "<109.5°, about 107° in NH₃",
+		"     ··",
+		"     A ",
+		"    ╱┊ ╲",
+		"  1  2   3 ",
+	),
+	shape(2, 2, "bent", "<109.5°, about 104.5° in H₂O",
+		"     ··",
+		"  ·· A ",
+		"    ╱ ╲",
+		"  1     2 ",
+	),
+	shape(5, 0, "trigonal bipyramidal", "90°, 120°",
+		"      1 ",
+		"      │",
+		"2  ── A  ── 3 ",
+		"     ╱│",
+		"   4  5 ",
+	),
+	shape(4, 1, "seesaw", "<90°, <120°",
+		"      1 ",
+		"      │",
+		"  ··  A  ── 2 ",
+		"     ╱│",
+		"   3  4 ",
+	),
+	shape(3, 2, "T-shaped", "<90°",
+		"      1 ",
+		"      │",
+		"  ··  A  ── 2 ",
+		"      │ ··",
+		"      3 ",
+	),
+	shape(2, 3, "linear", "180°",
+		"      ··",
+		"1  ── A  ── 2 ",
+		"   ··   ··",
+	),
+	shape(6, 0, "octahedral", "90°",
+		"      1 ",
+		"   2  │  3 ",
+		"    ╲ │ ╱",
+		"      A ",
+		"    ╱ │ ╲",
+		"   4  │  5 ",
+		"      6 ",
+	),
+	shape(5, 1, "square pyramidal", "<90°",
+		"      1 ",
+		"   2  │  3 ",
+		"    ╲ │ ╱",
+		"      A ",
+		"    ╱ ┊ ╲",
+		"   4  ┊  5 ",
+		"      ··",
+	),
+	shape(4, 2, "square planar", "90°",
+		"      1 ",
+		"      │  ··",
+		"2  ── A  ── 3 ",
+		"  ··  │",
+		"      4 ",
+	),
+	shape(3, 3, "T-shaped", "<90°",
+		"      1 ",
+		"      │  ··",
+		"  ··  A  ── 2 ",
+		"      │ ··",
+		"      3 ",
+	),
+	shape(2, 4, "linear", "180°",
+		"   ··   ··",
+		"1  ── A  ── 2 ",
+		"   ··   ··",
+	),
+	shape(7, 0, "pentagonal bipyramidal", "72°, 90°",
+		"        1 ",
+		"   2    │    3 ",
+		"     ╲  │  ╱",
+		"4  ──── A  ──── 5 ",
+		"     ╱  │",
+		"   6    │",
+		"        7 ",
+	),
+	shape(6, 1, "pentagonal pyramidal", "<72°, <90°",
+		"        1 ",
+		"   2    │    3 ",
+		"     ╲  │  ╱",
+		"4  ──── A  ──── 5 ",
+		"     ╱  ┊",
+		"   6    ┊",
+		"        ··",
+	),
+	shape(5, 2, "pentagonal planar", "72°",
+		"   1         2 ",
+		"     ╲  ··  ╱",
+		"3  ──── A  ──── 4 ",
+		"        │",
+		"        5 ",
+	),
+}
+
+// diatomic is the shape of a central atom with a single bond, which is
+// linear whatever its lone pairs.
+func diatomic(lonePairs int) Shape {
+	s := shape(1, lonePairs, "linear", "none, a single bond", "A  ── 1 ")
+	if lonePairs == 0 {
+		s.ElectronGeometry, s.Hybridization = "linear", "none"
+	}
+	return s
+}
@@ -0,0 +1,214 @@
+// Package vsepr predicts the shape of simple molecules and ions, a central
+// atom bonded to every other atom such as "SF4", "NH4+" or "XeO2F2", by
+// valence shell electron pair repulsion: the bonds and lone pairs around the
+// central atom, its electron domains, spread as far apart as they can.
+package vsepr
+
+import (
+	"errors"
+	"fmt"
+	"periodic-table/src/formula"
+	"strconv"
+	"strings"
+)
+
+// Shape is the arrangement of the electron domains around a central atom
+// with Bonds atoms bonded to it and LonePairs lone pairs.
+type Shape struct {
+	Bonds, LonePairs  int
+	ElectronGeometry  string
+	MolecularGeometry string
+	BondAngles        string
+	Hybridization     string
+	// sketch draws the shape: A stands for the central atom and 1 to 7 for
+	// the bonded atoms, each with the blank after it taking a second column
+	// for two letter symbols. Bonds to the right start after another blank.
+	sketch []string
+}
+
+// Domains returns the number of electron domains, bonds and lone pairs.
+func (s Shape) Domains() int {
+	return s.Bonds + s.LonePairs
+}
+
+// Summary returns the electron domains as bonds and lone pairs, such as
+// "4 bonds, 1 lone pair".
+func (s Shape) Summary() string {
+	summary := count(s.Bonds, "bond")
+	if s.LonePairs > 0 {
+		summary += ", " + count(s.LonePairs, "lone pair")
+	}
+	return summary
+}
+
+func count(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+// AXE returns the shape in AXE notation, such as "AX₂E₂" for water.
+func (s Shape) AXE() string {
+	axe := "AX" + formula.Subscript(strconv.Itoa(s.Bonds))
+	if s.LonePairs > 0 {
+		axe += "E" + formula.Subscript(strconv.Itoa(s.LonePairs))
+	}
+	return strings.NewReplacer("X₁", "X", "E₁", "E").Replace(axe)
+}
+
+// Molecule is the shape predicted for a formula.
+type Molecule struct {
+	Formula formula.Formula
+	Central formula.Atom
+	// Ligands are the atoms bonded to the central one, one per bond.
+	Ligands []formula.Atom
+	// OddElectron is set for radicals, whose central atom has an unpaired
+	// electron. It is counted as a lone pair.
+	OddElectron bool
+	Shape
+}
+
+// Predict works out the shape of a formula. The central atom is the only
+// atom of its element, other than hydrogen, and the least electronegative
+// if there are several, as in "POCl3". A formula of one element, such as
+// "O3" or "I3-", has one of its atoms in the middle.
+//
+// Bonds to hydrogen and halogens take one electron of the central atom, to
+// oxygen and sulfur two and to nitrogen three, as in the usual Lewis
+// structures. The electrons left over, after the charge, are lone pairs.
+func Predict(f formula.Formula) (Molecule, error) {
+	m := Molecule{Formula: f}
+	central, err := centralAtom(f)
+	if err != nil {
+		return m, err
+	}
+	m.Central = f.Atoms[central]
+
+	valence, ok := m.Central.Element.Valence()
+	if !ok {
+		return m, fmt.Errorf("%s is not a main-group element, whose shapes VSEPR predicts", m.Central.Symbol())
+	}
+	electrons := valence - f.Charge
+	for i, a := range f.Atoms {
+		count := a.Count
+		if i == central {
+			count--
+		}
+		if count == 0 {
+			continue
+		}
+		bonds, err := bondOrder(a)
+		if err != nil {
+			return m, err
+		}
+		for j := 0; j < count; j++ {
+			m.Ligands = append(m.Ligands, a)
+			electrons -= bonds
+		}
+	}
+
+	switch {
+	case len(m.Ligands) == 0:
+		return m, errors.New("expected atoms bonded to a central atom, such as in CH4 or SF6")
+	case electrons < 0:
+		return m, fmt.Errorf("%s has too few electrons for %d bonds", m.Central.Symbol(), len(m.Ligands))
+	}
+	m.OddElectron = electrons%2 != 0
+	lonePairs := (electrons + 1) / 2
+
+	for _, s := range shapes {
+		if s.Bonds == len(m.Ligands) && s.LonePairs == lonePairs {
+			m.Shape = s
+			return m, nil
+		}
+	}
+	if len(m.Ligands) == 1 {
+		m.Shape = diatomic(lonePairs)
+		return m, nil
+	}
+	return m, fmt.Errorf("%d bonds and %d lone pairs make more electron domains than VSEPR places", len(m.Ligands), lonePairs)
+}
+
+// centralAtom returns the index in f.Atoms of the central atom.
+func centralAtom(f formula.Formula) (int, error) {
+	central := -1
+	for i, a := range f.Atoms {
+		if a.Count != 1 || strings.TrimSpace(a.Element.Symbol) == "H" {
+			continue
+		}
+		if central < 0 || electronegativity(a) < electronegativity(f.Atoms[central]) {
+			central = i
+		}
+	}
+	if central < 0 && len(f.Atoms) == 1 {
+		central = 0
+	}
+	if central < 0 {
+		return 0, errors.New("expected a single central atom, such as in CH4 or SF6")
+	}
+	return central, nil
+}
+
+// electronegativity returns the Pauling electronegativity of the atom, or a
+// high one for noble gases which have none in the data, so that other atoms
+// are preferred at the center.
+func electronegativity(a formula.Atom) float64 {
+	if e, err := strconv.ParseFloat(a.Element.Electronegativity, 64); err == nil {
+		return e
+	}
+	return 5
+}
+
+// bondOrder returns the number of electrons the central atom shares with a
+// ligand, the number of bonds the ligand makes to fill its octet.
+func bondOrder(a formula.Atom) (int, error) {
+	if strings.TrimSpace(a.Element.Symbol) == "H" {
+		return 1, nil
+	}
+	valence, ok := a.Element.Valence()
+	if !ok || valence < 5 || valence > 7 {
+		return 0, fmt.Errorf("%s is not a ligand VSEPR knows: expected hydrogen, a halogen, oxygen, sulfur or nitrogen", a.Symbol())
+	}
+	return 8 - valence, nil
+}
+
+// Sketch draws the molecule with the symbols of its atoms, with lines for
+// bonds and dots for lone pairs, in ASCII if ascii is set.
+func (m Molecule) Sketch(ascii bool) string {
+	var lines []string
+	for _, line := range m.sketch {
+		var b strings.Builder
+		src := []rune(line)
+		for i := 0; i < len(src); i++ {
+			var a formula.Atom
+			switch r := src[i]; {
+			case r == 'A':
+				a = m.Central
+			case r >= '1' && r <= '9':
+				a = m.Ligands[r-'1']
+			default:
+				b.WriteRune(r)
+				continue
+			}
+			// One letter symbols leave the second column blank, and a bond
+			// to their right grows by the space before it.
+			symbol := strings.TrimSpace(a.Element.Symbol)
+			b.WriteString(symbol)
+			i++
+			if len(symbol) == 1 {
+				b.WriteString(" ")
+				if i+2 < len(src) && src[i+1] == ' ' && src[i+2] == '─' {
+					b.WriteString("─")
+					i++
+				}
+			}
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	sketch := strings.Join(lines, "\n")
+	if ascii {
+		sketch = strings.NewReplacer("─", "-", "│", "|", "╱", "/", "╲", "\\", "┊", ":", "·", ".").Replace(sketch)
+	}
+	return sketch
+}
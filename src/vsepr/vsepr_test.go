@@ -0,0 +1,127 @@
+package vsepr
+
+import (
+	"periodic-table/src/formula"
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+	"testing"
+)
+
+var testData = []element.Data{
+	{AtomicNumber: "1", Symbol: "H", Group: "1", Period: "1", NumberOfValence: "1", Electronegativity: "2.2"},
+	{AtomicNumber: "5", Symbol: "B", Group: "13", Period: "2", NumberOfValence: "3", Electronegativity: "2.04"},
+	{AtomicNumber: "6", Symbol: "C", Group: "14", Period: "2", NumberOfValence: "4", Electronegativity: "2.55"},
+	{AtomicNumber: "7", Symbol: "N", Group: "15", Period: "2", NumberOfValence: "5", Electronegativity: "3.04"},
+	{AtomicNumber: "8", Symbol: "O", Group: "16", Period: "2", NumberOfValence: "6", Electronegativity: "3.44"},
+	{AtomicNumber: "9", Symbol: "F", Group: "17", Period: "2", NumberOfValence: "7", Electronegativity: "3.98"},
+	{AtomicNumber: "15", Symbol: "P", Group: "15", Period: "3", NumberOfValence: "5", Electronegativity: "2.19"},
+	{AtomicNumber: "16", Symbol: "S", Group: "16", Period: "3", NumberOfValence: "6", Electronegativity: "2.58"},
+	{AtomicNumber: "17", Symbol: "Cl", Group: "17", Period: "3", NumberOfValence: "7", Electronegativity: "3.16"},
+	{AtomicNumber: "26", Symbol: "Fe", Group: "8", Period: "4", Electronegativity: "1.83"},
+	{AtomicNumber: "53", Symbol: "I", Group: "17", Period: "5", NumberOfValence: "7", Electronegativity: "2.66"},
+	{AtomicNumber: "54", Symbol: "Xe", Group: "18", Period: "5", NumberOfValence: "8"},
+}
+
+func TestPredict(t *testing.T) {
+	tests := []struct {
+		formula   string
+		central   string
+		axe       string
+		molecular string
+		hybrid    string
+	}{
+		{"CO2", "C", "AX₂", "linear", "sp"},
+		{"BF3", "B", "AX₃", "trigonal planar", "sp²"},
+		{"SO2", "S", "AX₂E", "bent", "sp²"},
+		{"CH4", "C", "AX₄", "tetrahedral", "sp³"},
+		{"NH3", "N", "AX₃E", "trigonal pyramidal", "sp³"},
+		{"H2O", "O", "AX₂E₂", "bent", "sp³"},
+		{"NH4+", "N", "AX₄", "tetrahedral", "sp³"},
+		{"SO4^2-", "S", "AX₄", "tetrahedral", "sp³"},
+		{"NO3-", "N", "AX₃", "trigonal planar", "sp²"},
+		{"POCl3", "P", "AX₄", "tetrahedral", "sp³"},
+		{"PCl5", "P", "AX₅", "trigonal bipyramidal", "sp³d"},
+		{"SF4", "S", "AX₄E", "seesaw", "sp³d"},
+		{"ClF3", "Cl", "AX₃E₂", "T-shaped", "sp³d"},
+		{"I3-", "I", "AX₂E₃", "linear", "sp³d"},
+		{"XeF2", "Xe", "AX₂E₃", "linear", "sp³d"},
+		{"SF6", "S", "AX₆", "octahedral", "sp³d²"},
+		{"IF5", "I", "AX₅E", "square pyramidal", "sp³d²"},
+		{"XeF4", "Xe", "AX₄E₂", "square planar", "sp³d²"},
+		{"XeO2F2", "Xe", "AX₄E", "seesaw", "sp³d"},
+		{"IF7", "I", "AX₇", "pentagonal bipyramidal", "sp³d³"},
+		{"O3", "O", "AX₂E", "bent", "sp²"},
+		{"HCl", "Cl", "AXE₃", "linear", "sp³"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			f, err := formula.Parse(tt.formula, testData)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			m, err := Predict(f)
+			if err != nil {
+				t.Fatalf("Predict() error = %v", err)
+			}
+			if got := m.Central.Symbol(); got != tt.central {
+				t.Errorf("Central = %s, want %s", got, tt.central)
+			}
+			if got := m.AXE(); got != tt.axe {
+				t.Errorf("AXE() = %s, want %s", got, tt.axe)
+			}
+			if m.MolecularGeometry != tt.molecular {
+				t.Errorf("MolecularGeometry = %s, want %s", m.MolecularGeometry, tt.molecular)
+			}
+			if m.Hybridization != tt.hybrid {
+				t.Errorf("Hybridization = %s, want %s", m.Hybridization, tt.hybrid)
+			}
+			sketch := m.Sketch(false)
+			for _, a := range append(m.Ligands, m.Central) {
+				if !strings.Contains(sketch, a.Symbol()) {
+					t.Errorf("Sketch() has no %s:\n%s", a.Symbol(), sketch)
+				}
+			}
+		})
+	}
+}
+
+func TestPredict_Errors(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    string
+	}{
+		{"FeCl3", "not a main-group element"},
+		{"C2H6", "expected a single central atom"},
+		{"CFe4", "not a ligand"},
+		{"BH5", "too few electrons"},
+		{"F", "expected atoms bonded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			f, err := formula.Parse(tt.formula, testData)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if _, err := Predict(f); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Predict() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMolecule_Sketch(t *testing.T) {
+	f, _ := formula.Parse("H2O", testData)
+	m, err := Predict(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"     ..",
+		"  .. O",
+		"    / \\",
+		"  H     H",
+	}, "\n")
+	if got := m.Sketch(true); got != want {
+		t.Errorf("Sketch() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"periodic-table/src/elements"
+	"periodic-table/src/formula"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/exp/slices"
+)
+
+var stoichFormats = []string{"table", "json"}
+
+type stoichOptions struct {
+	format string
+}
+
+func stoichFlags() (*flag.FlagSet, *stoichOptions) {
+	var o stoichOptions
+	fs := flag.NewFlagSet("stoich", flag.ContinueOnError)
+	fs.StringVar(&o.format, "format", "table", "output format: "+strings.Join(stoichFormats, ", "))
+	fs.Usage = commandUsage(fs, "stoich")
+	return fs, &o
+}
+
+// runStoich balances an equation and works out the limiting reagent and the
+// yields for the quantities given after it.
+func runStoich(args []string) int {
+	fs, o := stoichFlags()
+	words, err := parseArgs(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(words) < 2 {
+		fs.Usage()
+		return 2
+	}
+	if !slices.Contains(stoichFormats, o.format) {
+		fmt.Fprintf(os.Stderr, "%s stoich: unknown format %q, expected one of %s\n", program, o.format, strings.Join(stoichFormats, ", "))
+		return 2
+	}
+
+	e, err := formula.ParseEquation(words[0], elements.ReadData())
+	if err != nil {
+		printFormulaError(err)
+		return 2
+	}
+	if e, err = e.Balance(); err != nil {
+		printFormulaError(err)
+		return 1
+	}
+	quantities, err := formula.ParseQuantities(strings.Join(words[1:], " "), e)
+	if err != nil {
+		printFormulaError(err)
+		return 2
+	}
+	st, err := e.Solve(quantities)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s stoich: %s\n", program, err)
+		return 2
+	}
+
+	if o.format == "json" {
+		err = writeStoichJSON(os.Stdout, st)
+	} else {
+		err = writeStoichTable(os.Stdout, st)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s stoich: %s\n", program, err)
+		return 1
+	}
+	return 0
+}
+
+// writeStoichTable writes the balanced equation, then what happens to every
+// reactant and what forms of every product. Amounts are in grams and moles.
+func writeStoichTable(w io.Writer, st formula.Stoichiometry) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Reaction\t%s\n", st.Equation)
+	fmt.Fprintf(tw, "Limiting\t%s\n", st.Reactants[st.Limiting].Written())
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Reactant\tGiven\tReacted\tMoles\tLeft over\t")
+	for _, r := range st.Reactants {
+		given, left := "—", "—"
+		if r.Given != nil {
+			given = r.Given.String()
+			left = fmt.Sprintf("%.3f g", r.Grams(r.Left))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%.3f g\t%.4f\t%s\t\n", r.Written(), given, r.Grams(r.Moles), r.Moles, left)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Product\tActual\tTheoretical\tMoles\tYield\t")
+	for _, r := range st.Products {
+		actual, percent := "—", "—"
+		if r.Given != nil {
+			actual = r.Given.String()
+			percent = fmt.Sprintf("%.1f%%", r.Percent)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%.3f g\t%.4f\t%s\t\n", r.Written(), actual, r.Grams(r.Moles), r.Moles, percent)
+	}
+	return tw.Flush()
+}
+
+type stoichReagent struct {
+	Formula     string   `json:"formula"`
+	Coefficient int      `json:"coefficient"`
+	Given       *float64 `json:"given,omitempty"`
+	GivenUnit   string   `json:"given_unit,omitempty"`
+	Moles       float64  `json:"moles"`
+	Grams       float64  `json:"grams"`
+	LeftMoles   *float64 `json:"left_moles,omitempty"`
+	LeftGrams   *float64 `json:"left_grams,omitempty"`
+	Percent     *float64 `json:"percent_yield,omitempty"`
+}
+
+type stoichResult struct {
+	Equation  string          `json:"equation"`
+	Limiting  string          `json:"limiting"`
+	Reactants []stoichReagent `json:"reactants"`
+	Products  []stoichReagent `json:"products"`
+}
+
+func writeStoichJSON(w io.Writer, st formula.Stoichiometry) error {
+	reagent := func(r formula.Reagent) stoichReagent {
+		out := stoichReagent{
+			Formula:     r.Written(),
+			Coefficient: r.Coefficient,
+			Moles:       r.Moles,
+			Grams:       r.Grams(r.Moles),
+		}
+		if r.Given != nil {
+			out.Given, out.GivenUnit = &r.Given.Value, r.Given.Unit
+		}
+		return out
+	}
+	result := stoichResult{Equation: st.Equation.String(), Limiting: st.Reactants[st.Limiting].Written()}
+	for _, r := range st.Reactants {
+		out := reagent(r)
+		if r.Given != nil {
+			left, grams := r.Left, r.Grams(r.Left)
+			out.LeftMoles, out.LeftGrams = &left, &grams
+		}
+		result.Reactants = append(result.Reactants, out)
+	}
+	for _, r := range st.Products {
+		out := reagent(r)
+		if r.Given != nil {
+			percent := r.Percent
+			out.Percent = &percent
+		}
+		result.Products = append(result.Products, out)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+func completeStoich(args []string, word string) []string {
+	fs, _ := stoichFlags()
+	candidates, _ := completeFlag(fs, map[string]func() []string{"format": constant(stoichFormats...)}, args, word)
+	return candidates
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+
+	"periodic-table/src/config"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/theme"
+	"periodic-table/ui/periodic_table/views"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reloadInterval is how often the config file is checked for changes.
+const reloadInterval = time.Second
+
+// configMsg reports the state of the config file after a check. changed is
+// false when the file was not modified since the last check.
+type configMsg struct {
+	config  config.Config
+	modTime time.Time
+	changed bool
+	err     error
+}
+
+// watchConfig checks the config file at path and the other files it was
+// read from, such as a custom theme, for changes after a delay. A config file
+// which is removed goes back to the default settings.
+func watchConfig(path string, files []string, modTime time.Time) tea.Cmd {
+	return tea.Tick(reloadInterval, func(time.Time) tea.Msg {
+		info, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return configMsg{config: config.Default(), changed: !modTime.IsZero()}
+		} else if err != nil {
+			return configMsg{modTime: modTime, changed: true, err: err}
+		}
+
+		latest := latestModTime(info.ModTime(), files)
+		if latest.Equal(modTime) {
+			return configMsg{modTime: modTime}
+		}
+		c, err := config.Load(path)
+		return configMsg{config: c, modTime: latest, changed: true, err: err}
+	})
+}
+
+// latestModTime returns the latest of modTime and the modification times of
+// files. Files which can't be read are skipped.
+func latestModTime(modTime time.Time, files []string) time.Time {
+	for _, f := range files {
+		if info, err := os.Stat(f); err == nil && info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime
+}
+
+// applyConfig applies the display settings and key remaps and passes the
+// settings on to every view.
+func (m *Model) applyConfig(c config.Config) {
+	m.configFiles = c.Files()
+	theme.Set(c.ResolvedTheme())
+	theme.SetProfile(theme.Profiles[c.Profile])
+	element.SetTemperatureUnit(element.TemperatureUnits[c.Units.Temperature])
+	element.SetCellFields(c.Cell.Top, c.Cell.Bottom)
+	element.SetInfoFields(c.InfoPanel)
+	if err := keys.Apply(c.Keys); err != nil {
+		m.configErr = err
+	}
+
+	msg := views.ConfigMsg{Config: c}
+	m.table, _ = m.table.Update(msg)
+	m.element, _ = m.element.Update(msg)
+	m.list, _ = m.list.Update(msg)
+	m.compare, _ = m.compare.Update(msg)
+	m.calculator, _ = m.calculator.Update(msg)
+	if m.accessible != nil {
+		m.accessible, _ = m.accessible.Update(msg)
+	}
+}
@@ -7,9 +7,12 @@ type Cell interface {
 	GetView() string
 	SetStyle(selectedStyle lipgloss.Style, unSelectedStyle lipgloss.Style)
 	SetSelected(isSelected bool)
+	SetHighlighted(isHighlighted bool)
+	SetDimmed(isDimmed bool)
+	SetMarked(isMarked bool)
 	GetUnselectedStyle() lipgloss.Style
 	GetSelectedStyle() lipgloss.Style
-	GetSearchString() string
+	GetSearchStrings() []string
 	GetData() interface{}
 	IsPaddingCell() bool
 }
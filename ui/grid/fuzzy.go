@@ -0,0 +1,62 @@
+package grid
+
+import "strings"
+
+const (
+	exactScore     = 1000
+	prefixScore    = 800
+	substringScore = 600
+	maxFuzzyScore  = 500
+)
+
+// fuzzyScore rates how well pattern matches candidate. Exact, prefix and
+// substring matches always rank above matches where the pattern characters
+// are only found in order with gaps between them.
+func fuzzyScore(pattern string, candidate string) (int, bool) {
+	pattern = strings.ToLower(pattern)
+	candidate = strings.ToLower(candidate)
+
+	if pattern == "" || candidate == "" {
+		return 0, false
+	}
+
+	switch {
+	case candidate == pattern:
+		return exactScore, true
+	case strings.HasPrefix(candidate, pattern):
+		return prefixScore - len(candidate), true
+	case strings.Contains(candidate, pattern):
+		return substringScore - strings.Index(candidate, pattern) - len(candidate), true
+	}
+
+	var score int
+	last := -1
+	p := 0
+	for c := 0; c < len(candidate) && p < len(pattern); c++ {
+		if candidate[c] != pattern[p] {
+			continue
+		}
+
+		score += 10
+		if c == 0 || candidate[c-1] == ' ' {
+			score += 20
+		}
+		if last == c-1 {
+			score += 15
+		} else if last >= 0 {
+			score -= c - last - 1
+		}
+		last = c
+		p++
+	}
+
+	if p < len(pattern) {
+		return 0, false
+	}
+
+	score -= len(candidate)
+	if score > maxFuzzyScore {
+		score = maxFuzzyScore
+	}
+	return score, true
+}
@@ -2,11 +2,12 @@ package grid
 
 import (
 	"fmt"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"golang.org/x/exp/slices"
-	"strings"
+	"sort"
 )
 
 var (
@@ -25,6 +26,9 @@ type Model struct {
 	grid                 [][]*Cell
 	selectedX, selectedY int
 	maxHeight            int
+	matches              []int
+	activeMatch          int
+	keyMap               KeyMap
 }
 
 func getDirectionFromKey(directionKey string) (direction string) {
@@ -60,6 +64,49 @@ func (m *Model) setSelectedCell(idx int) {
 	(*m.grid[m.selectedY][m.selectedX]).SetSelected(true)
 }
 
+// SelectNext moves the selection to the next cell, in reading order, for which
+// match returns true. The search wraps around the grid and skips padding cells.
+// When reverse is set the grid is searched backwards. It returns false if no
+// other cell matches.
+func (m *Model) SelectNext(match func(c Cell) bool, reverse bool) bool {
+	if m.grid == nil {
+		return false
+	}
+
+	count := len(m.cells)
+	current := m.selectedY*len(m.grid[0]) + m.selectedX
+	step := 1
+	if reverse {
+		step = -1
+	}
+
+	for i := 1; i < count; i++ {
+		idx := ((current+step*i)%count + count) % count
+		if !m.cells[idx].IsPaddingCell() && match(m.cells[idx]) {
+			m.setSelectedCell(idx)
+			return true
+		}
+	}
+	return false
+}
+
+// SelectFirst selects the first cell, in reading order, for which match
+// returns true. It returns false if no cell matches.
+func (m *Model) SelectFirst(match func(c Cell) bool) bool {
+	if m.grid == nil {
+		return false
+	}
+
+	idx := slices.IndexFunc(m.cells, func(c Cell) bool {
+		return !c.IsPaddingCell() && match(c)
+	})
+	if idx == -1 {
+		return false
+	}
+	m.setSelectedCell(idx)
+	return true
+}
+
 func (m *Model) getNextNonHiddenCell(direction string) (int, int) {
 	planeValue := m.selectedX
 	planeValueModifier := func() { planeValue++ }
@@ -201,10 +248,15 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		key := msg.String()
-		switch key {
-		case "up", "down", "left", "right", "h", "j", "k", "l":
-			m.SelectCell(key)
+		switch {
+		case key.Matches(msg, m.keyMap.Up):
+			m.SelectCell("up")
+		case key.Matches(msg, m.keyMap.Down):
+			m.SelectCell("down")
+		case key.Matches(msg, m.keyMap.Left):
+			m.SelectCell("left")
+		case key.Matches(msg, m.keyMap.Right):
+			m.SelectCell("right")
 		}
 	}
 
@@ -213,6 +265,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// SetKeyMap replaces the keys which move the selection.
+func (m *Model) SetKeyMap(keyMap KeyMap) {
+	m.keyMap = keyMap
+}
+
 func (m *Model) View() string {
 	var text string
 	for _, row := range m.grid {
@@ -234,17 +291,119 @@ func (m *Model) GetHeight() int {
 	return len(m.grid) * (m.cells[0].GetUnselectedStyle().GetVerticalFrameSize() + m.cells[0].GetUnselectedStyle().GetHeight() + 1)
 }
 
+// SearchCells ranks every cell against searchText, highlights all matching
+// cells and selects the best match. An empty searchText clears the results.
 func (m *Model) SearchCells(searchText string) {
-	searchText = strings.ToLower(searchText)
-	idx := slices.IndexFunc(m.cells, func(c Cell) bool {
-		cellString := strings.ToLower(c.GetSearchString())
-		if len(cellString) < len(searchText) {
-			return false
+	m.ClearSearch()
+	if searchText == "" {
+		return
+	}
+
+	type result struct {
+		idx   int
+		score int
+	}
+
+	var results []result
+	for i, c := range m.cells {
+		if c.IsPaddingCell() {
+			continue
+		}
+
+		best, found := 0, false
+		for _, s := range c.GetSearchStrings() {
+			if score, ok := fuzzyScore(searchText, s); ok && (!found || score > best) {
+				best, found = score, true
+			}
 		}
-		return cellString[:len(searchText)] == searchText
+		if found {
+			results = append(results, result{idx: i, score: best})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
 	})
-	if idx != -1 {
-		m.setSelectedCell(idx)
+
+	for _, r := range results {
+		m.matches = append(m.matches, r.idx)
+		m.cells[r.idx].SetHighlighted(true)
+	}
+
+	if len(m.matches) > 0 {
+		m.setSelectedCell(m.matches[0])
+	}
+}
+
+// NextMatch selects the next search result, wrapping around at the end.
+func (m *Model) NextMatch() {
+	m.cycleMatch(1)
+}
+
+// PrevMatch selects the previous search result, wrapping around at the start.
+func (m *Model) PrevMatch() {
+	m.cycleMatch(-1)
+}
+
+func (m *Model) cycleMatch(step int) {
+	if len(m.matches) == 0 {
+		return
+	}
+
+	m.activeMatch = ((m.activeMatch+step)%len(m.matches) + len(m.matches)) % len(m.matches)
+	m.setSelectedCell(m.matches[m.activeMatch])
+}
+
+// ClearSearch removes the results and highlighting of the last search.
+func (m *Model) ClearSearch() {
+	for _, idx := range m.matches {
+		m.cells[idx].SetHighlighted(false)
+	}
+	m.matches = nil
+	m.activeMatch = 0
+}
+
+// GetCells returns every cell of the grid in reading order.
+func (m *Model) GetCells() []Cell {
+	return m.cells
+}
+
+// GetMatches returns the cells found by the last search, best match first.
+func (m *Model) GetMatches() []Cell {
+	var cells []Cell
+	for _, idx := range m.matches {
+		cells = append(cells, m.cells[idx])
+	}
+	return cells
+}
+
+// GetActiveMatch returns the position of the selected cell in GetMatches.
+func (m *Model) GetActiveMatch() int {
+	return m.activeMatch
+}
+
+// FilterCells dims every cell for which match returns false and returns the
+// number of cells that matched.
+func (m *Model) FilterCells(match func(c Cell) bool) int {
+	var count int
+	for _, c := range m.cells {
+		if c.IsPaddingCell() {
+			continue
+		}
+
+		matched := match(c)
+		c.SetDimmed(!matched)
+		if matched {
+			count++
+		}
+	}
+	return count
+}
+
+// ClearFilter undims every cell.
+func (m *Model) ClearFilter() {
+	for _, c := range m.cells {
+		c.SetDimmed(false)
 	}
 }
 
@@ -253,7 +412,8 @@ func CreateModel(cells []Cell, gridSettings GridSettings) (Model, error) {
 	search.Prompt = "Search: "
 
 	model := Model{
-		cells: cells,
+		cells:  cells,
+		keyMap: DefaultKeyMap(),
 	}
 	err := model.SetGrid(gridSettings)
 
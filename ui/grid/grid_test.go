@@ -1,35 +1,42 @@
 package grid
 
 import (
-	"github.com/charmbracelet/lipgloss"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 type mockCell struct {
-	SelectedStyle   lipgloss.Style
-	UnSelectedStyle lipgloss.Style
-	SearchString    string
-	IsSelected      bool
-	IsPaddingCell   bool
-	View            string
+	selectedStyle   lipgloss.Style
+	unSelectedStyle lipgloss.Style
+	searchString    string
+	isSelected      bool
+	isHighlighted   bool
+	isDimmed        bool
+	isPaddingCell   bool
+	view            string
 }
 
-func (c *mockCell) GetSearchString() string {
-	return c.searchString
+func (c *mockCell) GetSearchStrings() []string {
+	return []string{c.searchString}
 }
 
 func (c *mockCell) GetData() interface{} {
-	return "no data"
+	return c.searchString
 }
 
 func (c *mockCell) GetView() string {
-	return c.View
+	return c.view
 }
 
 func (c *mockCell) GetUnselectedStyle() lipgloss.Style {
 	return c.unSelectedStyle
 }
 
+func (c *mockCell) GetSelectedStyle() lipgloss.Style {
+	return c.selectedStyle
+}
+
 func (c *mockCell) SetStyle(selectedStyle lipgloss.Style, unSelectedStyle lipgloss.Style) {
 	c.selectedStyle = selectedStyle
 	c.unSelectedStyle = unSelectedStyle
@@ -39,10 +46,27 @@ func (c *mockCell) SetSelected(isSelected bool) {
 	c.isSelected = isSelected
 }
 
-func styleText(atomicNumber string, symbol string) string {
-	text := lipgloss.Place(width, height, 1, 1, symbol)
-	text = lipgloss.JoinVertical(0, lipgloss.Place(0, 0, 0, 0, atomicNumber), text)
-	return text
+func (c *mockCell) SetHighlighted(isHighlighted bool) {
+	c.isHighlighted = isHighlighted
+}
+
+func (c *mockCell) SetDimmed(isDimmed bool) {
+	c.isDimmed = isDimmed
+}
+
+func (c *mockCell) SetMarked(isMarked bool) {
+}
+
+func (c *mockCell) IsPaddingCell() bool {
+	return c.isPaddingCell
+}
+
+func createMockCells(names ...string) []Cell {
+	var cells []Cell
+	for _, name := range names {
+		cells = append(cells, &mockCell{searchString: name, isPaddingCell: name == ""})
+	}
+	return cells
 }
 
 func TestModel_SetGrid(t *testing.T) {
@@ -125,14 +149,14 @@ func TestModel_SetGrid(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var cells []mockCell
+			var cells []Cell
 			for i := 0; i < tt.args.numberOfElements; i++ {
-				elements = append(elements, element.Model{})
+				cells = append(cells, &mockCell{})
 			}
 
-			m := &Model{}
+			m := &Model{cells: cells}
 
-			err := m.SetGrid(elements, tt.args.settings)
+			err := m.SetGrid(tt.args.settings)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SetGrid() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -147,3 +171,146 @@ func TestModel_SetGrid(t *testing.T) {
 		})
 	}
 }
+
+func TestModel_SelectNext(t *testing.T) {
+	cells := createMockCells(
+		"a1", "", "b1",
+		"a2", "b2", "",
+		"", "a3", "b3",
+	)
+	startsWith := func(prefix string) func(c Cell) bool {
+		return func(c Cell) bool {
+			return c.(*mockCell).searchString[:1] == prefix
+		}
+	}
+
+	tests := []struct {
+		name    string
+		start   int
+		match   func(c Cell) bool
+		reverse bool
+		want    string
+		wantOk  bool
+	}{
+		{name: "next match in reading order", start: 0, match: startsWith("a"), want: "a2", wantOk: true},
+		{name: "wraps around the end", start: 7, match: startsWith("a"), want: "a1", wantOk: true},
+		{name: "searches backwards", start: 3, match: startsWith("b"), reverse: true, want: "b1", wantOk: true},
+		{name: "wraps around the start", start: 0, match: startsWith("b"), reverse: true, want: "b3", wantOk: true},
+		{name: "no other match keeps selection", start: 0, match: startsWith("c"), want: "a1", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := CreateModel(cells, GridSettings{Rows: 3, Columns: 3})
+			if err != nil {
+				t.Fatalf("CreateModel() error = %v", err)
+			}
+			m.setSelectedCell(tt.start)
+
+			ok := m.SelectNext(tt.match, tt.reverse)
+			if ok != tt.wantOk {
+				t.Errorf("SelectNext() = %v, want %v", ok, tt.wantOk)
+			}
+			if got := (*m.GetActiveCell()).GetData(); got != tt.want {
+				t.Errorf("SelectNext() selected %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_SelectFirst(t *testing.T) {
+	m, err := CreateModel(createMockCells("", "x", "y", "x"), GridSettings{Rows: 2, Columns: 2})
+	if err != nil {
+		t.Fatalf("CreateModel() error = %v", err)
+	}
+
+	if !m.SelectFirst(func(c Cell) bool { return c.(*mockCell).searchString == "y" }) {
+		t.Fatalf("SelectFirst() = false, want true")
+	}
+	if m.selectedX != 0 || m.selectedY != 1 {
+		t.Errorf("SelectFirst() selected (%d, %d), want (0, 1)", m.selectedX, m.selectedY)
+	}
+	if m.SelectFirst(func(c Cell) bool { return c.(*mockCell).searchString == "" }) {
+		t.Errorf("SelectFirst() matched a padding cell")
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	ranked := []string{"fe", "ferrum", "fermium", "coffee", "fluorine"}
+
+	var last int
+	for i, candidate := range ranked {
+		score, ok := fuzzyScore("fe", candidate)
+		if !ok {
+			t.Fatalf("fuzzyScore(%q) did not match", candidate)
+		}
+		if i > 0 && score > last {
+			t.Errorf("fuzzyScore(%q) = %d ranks above %q (%d)", candidate, score, ranked[i-1], last)
+		}
+		last = score
+	}
+
+	if _, ok := fuzzyScore("fe", "iron"); ok {
+		t.Errorf("fuzzyScore(\"iron\") matched")
+	}
+}
+
+func TestModel_SearchCells(t *testing.T) {
+	cells := createMockCells("Fermium", "", "Iron", "Fe")
+	m, err := CreateModel(cells, GridSettings{Rows: 2, Columns: 2})
+	if err != nil {
+		t.Fatalf("CreateModel() error = %v", err)
+	}
+
+	m.SearchCells("fe")
+	if got := len(m.GetMatches()); got != 2 {
+		t.Fatalf("SearchCells() found %d matches, want 2", got)
+	}
+	if got := (*m.GetActiveCell()).GetData(); got != "Fe" {
+		t.Errorf("SearchCells() selected %v, want Fe", got)
+	}
+	if !cells[0].(*mockCell).isHighlighted || cells[2].(*mockCell).isHighlighted {
+		t.Errorf("SearchCells() highlighted the wrong cells")
+	}
+
+	m.NextMatch()
+	if got := (*m.GetActiveCell()).GetData(); got != "Fermium" {
+		t.Errorf("NextMatch() selected %v, want Fermium", got)
+	}
+	m.NextMatch()
+	if got := (*m.GetActiveCell()).GetData(); got != "Fe" {
+		t.Errorf("NextMatch() selected %v, want Fe", got)
+	}
+
+	m.ClearSearch()
+	if len(m.GetMatches()) != 0 || cells[0].(*mockCell).isHighlighted {
+		t.Errorf("ClearSearch() left results behind")
+	}
+}
+
+func TestModel_FilterCells(t *testing.T) {
+	cells := createMockCells("a1", "", "b1", "a2")
+	m, err := CreateModel(cells, GridSettings{Rows: 2, Columns: 2})
+	if err != nil {
+		t.Fatalf("CreateModel() error = %v", err)
+	}
+
+	count := m.FilterCells(func(c Cell) bool {
+		return c.(*mockCell).searchString[0] == 'a'
+	})
+	if count != 2 {
+		t.Errorf("FilterCells() = %d, want 2", count)
+	}
+
+	var dimmed []bool
+	for _, c := range cells {
+		dimmed = append(dimmed, c.(*mockCell).isDimmed)
+	}
+	if dimmed[0] || dimmed[1] || !dimmed[2] || dimmed[3] {
+		t.Errorf("FilterCells() dimmed %v, want [false false true false]", dimmed)
+	}
+
+	m.ClearFilter()
+	if cells[2].(*mockCell).isDimmed {
+		t.Errorf("ClearFilter() left cells dimmed")
+	}
+}
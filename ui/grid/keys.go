@@ -0,0 +1,20 @@
+package grid
+
+import "github.com/charmbracelet/bubbles/key"
+
+// KeyMap defines the keys which move the selection around the grid.
+type KeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Left  key.Binding
+	Right key.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:    key.NewBinding(key.WithKeys("up", "k")),
+		Down:  key.NewBinding(key.WithKeys("down", "j")),
+		Left:  key.NewBinding(key.WithKeys("left", "h")),
+		Right: key.NewBinding(key.WithKeys("right", "l")),
+	}
+}
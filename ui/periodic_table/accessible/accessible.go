@@ -0,0 +1,250 @@
+// Package accessible is a linear view of the table for screen readers. It
+// draws no box art: every move prints one line describing the element moved
+// to, using the same grid navigation as the table.
+package accessible
+
+import (
+	"fmt"
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/navigation"
+	"periodic-table/ui/periodic_table/theme"
+	"periodic-table/ui/periodic_table/views"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type model struct {
+	grid      grid.Model
+	keys      keys.KeyMap
+	search    textinput.Model
+	searching bool
+	count     string
+}
+
+// Init announces the mode and the element selected at the start.
+func (m model) Init() tea.Cmd {
+	return announce(fmt.Sprintf("Periodic table. Press %s for keys.", m.keys.Help.Help().Key), m.describeActive())
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case views.ConfigMsg:
+		m.keys = keys.CreateKeys()
+		m.grid.SetKeyMap(gridKeys(m.keys))
+	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		if key.Matches(msg, m.keys.Quit) {
+			return m, tea.Quit
+		}
+		return m, m.navigate(msg)
+	}
+	return m, nil
+}
+
+// navigate handles a key outside of search. Digits are collected into a
+// count for the go to key, as in the table.
+func (m *model) navigate(msg tea.KeyMsg) tea.Cmd {
+	if s := msg.String(); len(s) == 1 && s[0] >= '0' && s[0] <= '9' {
+		m.count += s
+		return nil
+	}
+	count := m.count
+	m.count = ""
+
+	switch {
+	case key.Matches(msg, m.keys.Help):
+		return announce(m.helpLines()...)
+	case key.Matches(msg, m.keys.Details):
+		if d, ok := m.activeElement(); ok {
+			return announce(strings.Split(d.GetDataAsString(), "\n")...)
+		}
+		return nil
+	case key.Matches(msg, m.keys.Search):
+		m.searching = true
+		m.search.Focus()
+		return announce("Search, type a name, symbol or number and press enter.")
+	case key.Matches(msg, m.keys.Cancel):
+		m.grid.ClearSearch()
+		return announce("Search cleared.")
+	case key.Matches(msg, m.keys.NextMatch), key.Matches(msg, m.keys.PrevMatch):
+		return m.cycleMatch(key.Matches(msg, m.keys.NextMatch))
+	}
+
+	before := m.grid.GetActiveCell()
+	var moved bool
+	property, reverse, related := navigation.Related(m.keys, msg)
+	switch {
+	case related:
+		moved = navigation.SelectRelated(&m.grid, property, reverse)
+	case key.Matches(msg, m.keys.Up, m.keys.Down, m.keys.Left, m.keys.Right):
+		m.grid, _ = m.grid.Update(msg)
+	case key.Matches(msg, m.keys.GoTo):
+		number, err := strconv.Atoi(count)
+		if count == "" || err != nil {
+			return announce(fmt.Sprintf("Type an atomic number, then %s.", msg.String()))
+		}
+		if !navigation.GoTo(&m.grid, number) {
+			return announce(fmt.Sprintf("No element with atomic number %s.", count))
+		}
+		moved = true
+	default:
+		return nil
+	}
+
+	if !moved && m.grid.GetActiveCell() == before {
+		return announce("No element there, still on " + m.describeActive() + ".")
+	}
+	return announce(m.describeActive())
+}
+
+// updateSearch edits the search until it is accepted, then announces the
+// number of results and the best one. Quit keys other than letters still
+// quit, as in the table.
+func (m model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit) && msg.Type != tea.KeyRunes:
+		return m, tea.Quit
+	case key.Matches(msg, m.keys.Cancel):
+		m.searching = false
+		m.search.Reset()
+		return m, announce("Search cancelled, on " + m.describeActive() + ".")
+	case key.Matches(msg, m.keys.Confirm):
+		m.searching = false
+		text := m.search.Value()
+		m.search.Reset()
+		m.grid.SearchCells(text)
+		matches := len(m.grid.GetMatches())
+		switch matches {
+		case 0:
+			return m, announce(fmt.Sprintf("No results for %q, still on %s.", text, m.describeActive()))
+		case 1:
+			return m, announce("1 result: " + m.describeActive())
+		}
+		return m, announce(fmt.Sprintf("%d results, press %s for the next. Result 1: %s", matches,
+			m.keys.NextMatch.Help().Key, m.describeActive()))
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	return m, cmd
+}
+
+func (m *model) cycleMatch(next bool) tea.Cmd {
+	matches := len(m.grid.GetMatches())
+	if matches == 0 {
+		return announce("No search results.")
+	}
+	if next {
+		m.grid.NextMatch()
+	} else {
+		m.grid.PrevMatch()
+	}
+	return announce(fmt.Sprintf("Result %d of %d: %s", m.grid.GetActiveMatch()+1, matches, m.describeActive()))
+}
+
+func (m model) View() string {
+	if m.searching {
+		return m.search.View()
+	}
+	return ""
+}
+
+func (m model) activeElement() (element.Data, bool) {
+	return navigation.ActiveElement(&m.grid)
+}
+
+func (m model) describeActive() string {
+	if d, ok := m.activeElement(); ok {
+		return Describe(d)
+	}
+	return "no element"
+}
+
+// Describe returns the line read out for d, such as "Iron, Fe, atomic number
+// 26, period 4, group 8, transition metal".
+func Describe(d element.Data) string {
+	parts := []string{d.Element, d.Symbol, "atomic number " + d.AtomicNumber}
+	if d.Period != "" {
+		parts = append(parts, "period "+d.Period)
+	}
+	if d.Group != "" {
+		parts = append(parts, "group "+d.Group)
+	}
+	if d.Type != "" {
+		parts = append(parts, strings.ToLower(d.Type))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// helpLines lists the keys of the mode, one per line.
+func (m model) helpLines() []string {
+	bindings := []struct {
+		binding key.Binding
+		action  string
+	}{
+		{m.keys.Up, "move up"},
+		{m.keys.Down, "move down"},
+		{m.keys.Left, "move left"},
+		{m.keys.Right, "move right"},
+		{m.keys.NextType, "next element of the same type"},
+		{m.keys.PrevType, "previous element of the same type"},
+		{m.keys.NextGroup, "next element in the group"},
+		{m.keys.PrevGroup, "previous element in the group"},
+		{m.keys.NextPeriod, "next element in the period"},
+		{m.keys.PrevPeriod, "previous element in the period"},
+		{m.keys.NextBlock, "next element in the block"},
+		{m.keys.PrevBlock, "previous element in the block"},
+		{m.keys.GoTo, "go to an atomic number typed before it"},
+		{m.keys.Details, "read the properties of the element"},
+		{m.keys.Search, "search"},
+		{m.keys.NextMatch, "next search result"},
+		{m.keys.PrevMatch, "previous search result"},
+		{m.keys.Cancel, "clear the search"},
+		{m.keys.Quit, "quit"},
+	}
+
+	var lines []string
+	for _, b := range bindings {
+		if b.binding.Enabled() {
+			lines = append(lines, strings.Join(b.binding.Keys(), " or ")+": "+b.action)
+		}
+	}
+	return lines
+}
+
+// announce prints lines above the view, where they stay for a screen reader
+// to read.
+func announce(lines ...string) tea.Cmd {
+	return tea.Println(theme.ASCII(strings.Join(lines, "\n")))
+}
+
+// gridKeys returns the keys of the table which move the selection.
+func gridKeys(k keys.KeyMap) grid.KeyMap {
+	return grid.KeyMap{Up: k.Up, Down: k.Down, Left: k.Left, Right: k.Right}
+}
+
+func newSearch() textinput.Model {
+	search := textinput.New()
+	search.Prompt = "Search: "
+	search.SetCursorMode(textinput.CursorStatic)
+	return search
+}
+
+func CreateModel(cells []grid.Cell) (tea.Model, error) {
+	g, err := grid.CreateModel(cells, grid.GridSettings{Rows: 10, Columns: 18})
+	if err != nil {
+		return nil, err
+	}
+	keyMap := keys.CreateKeys()
+	g.SetKeyMap(gridKeys(keyMap))
+
+	return model{grid: g, keys: keyMap, search: newSearch()}, nil
+}
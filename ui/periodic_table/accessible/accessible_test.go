@@ -0,0 +1,113 @@
+package accessible
+
+import (
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/keys"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		data element.Data
+		want string
+	}{
+		{
+			element.Data{Element: "Iron", Symbol: "Fe", AtomicNumber: "26", Period: "4", Group: "8", Type: "Transition Metal"},
+			"Iron, Fe, atomic number 26, period 4, group 8, transition metal",
+		},
+		{
+			element.Data{Element: "Cerium", Symbol: "Ce", AtomicNumber: "58", Period: "6", Type: "Lanthanide"},
+			"Cerium, Ce, atomic number 58, period 6, lanthanide",
+		},
+	}
+	for _, tt := range tests {
+		if got := Describe(tt.data); got != tt.want {
+			t.Errorf("Describe() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	data := []element.Data{
+		{Element: "Scandium", Symbol: "Sc", AtomicNumber: "21", Group: "3", Type: "Transition Metal"},
+		{Element: "Titanium", Symbol: "Ti", AtomicNumber: "22", Group: "4", Type: "Transition Metal"},
+		{Element: "Iron", Symbol: "Fe", AtomicNumber: "26", Group: "8", Type: "Transition Metal"},
+		{Element: "Yttrium", Symbol: "Y", AtomicNumber: "39", Group: "3", Type: "Transition Metal"},
+		{Element: "Zirconium", Symbol: "Zr", AtomicNumber: "40", Group: "4", Type: "Transition Metal"},
+		{Symbol: ""},
+	}
+	var cells []grid.Cell
+	for _, d := range data {
+		cells = append(cells, element.CreateElement(d, d.Symbol == ""))
+	}
+
+	tests := []struct {
+		name string
+		keys []string
+		want string
+	}{
+		{"move", []string{"l", "j"}, "Zr"},
+		{"edge", []string{"k"}, "Sc"},
+		{"padding", []string{"l", "l", "j"}, "Fe"},
+		{"go to", []string{"2", "6", "G"}, "Fe"},
+		{"unknown number", []string{"9", "G"}, "Sc"},
+		{"group", []string{"c"}, "Y"},
+		{"block", []string{"b"}, "Ti"},
+		{"previous block", []string{"B"}, "Zr"},
+		{"search", []string{"/", "i", "r", "o", "n", "enter"}, "Fe"},
+		{"search results", []string{"/", "i", "u", "m", "enter", "n"}, "Sc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := grid.CreateModel(cells, grid.GridSettings{Rows: 2, Columns: 3})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var m tea.Model = model{grid: g, keys: keys.CreateKeys(), search: newSearch()}
+			for _, k := range tt.keys {
+				msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+				if k == "enter" {
+					msg = tea.KeyMsg{Type: tea.KeyEnter}
+				}
+				m, _ = m.Update(msg)
+			}
+
+			if d, _ := m.(model).activeElement(); d.Symbol != tt.want {
+				t.Errorf("selected %q, want %q", d.Symbol, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdate_QuitWhileSearching(t *testing.T) {
+	tests := []struct {
+		name  string
+		msg   tea.KeyMsg
+		quits bool
+	}{
+		{"ctrl+c", tea.KeyMsg{Type: tea.KeyCtrlC}, true},
+		{"q", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := grid.CreateModel([]grid.Cell{element.CreateElement(element.Data{Symbol: "H"}, false)}, grid.GridSettings{Rows: 1, Columns: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var m tea.Model = model{grid: g, keys: keys.CreateKeys(), search: newSearch()}
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+			_, cmd := m.Update(tt.msg)
+
+			var quits bool
+			if cmd != nil {
+				quits = cmd() == tea.Quit()
+			}
+			if quits != tt.quits {
+				t.Errorf("Update(%s) quits = %v, want %v", tt.name, quits, tt.quits)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package calculator
+
+import (
+	"fmt"
+	"periodic-table/src/formula"
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+)
+
+// balanceView balances an equation and counts the atoms on both sides to
+// show that they match.
+func balanceView(input string, data []element.Data) (string, error) {
+	e, err := formula.ParseEquation(input, data)
+	if err != nil {
+		return "", err
+	}
+	balanced, err := e.Balance()
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{
+		valueStyle.Render(balanced.String()),
+		"",
+		headerStyle.Render(fmt.Sprintf("%-8s %10s %10s", "Element", "Reactants", "Products")),
+	}
+	for _, t := range balanced.Tallies() {
+		symbol := t.Symbol
+		if symbol == "charge" {
+			symbol = "Charge"
+			lines = append(lines, fmt.Sprintf("%s %+10d %+10d", padRight(symbol, 8), t.Reactants, t.Products))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s %10d %10d", padRight(symbol, 8), t.Reactants, t.Products))
+	}
+	return strings.Join(lines, "\n"), nil
+}
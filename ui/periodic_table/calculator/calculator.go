@@ -0,0 +1,206 @@
+// Package calculator works out quantities from formulas as they are typed.
+// Each tool is a tab with an input of its own, and the result below it is
+// redone on every key.
+package calculator
+
+import (
+	"errors"
+	"periodic-table/src/formula"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/views"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// headerHeight is the number of lines above and below the scrollable
+// result: the tabs, the input, the error line, blank lines and the help bar.
+const headerHeight = 6
+
+// tool is one tab of the calculator.
+type tool struct {
+	name        string
+	prompt      string
+	placeholder string
+	hint        string
+	// render returns the result for the input, or an error which may point
+	// at a position in it.
+	render func(input string, data []element.Data) (string, error)
+}
+
+var tools = []tool{
+	{
+		name:        "Molar mass",
+		prompt:      "Formula: ",
+		placeholder: "CuSO4·5H2O",
+		hint:        "Type a formula such as H2O, Ca(OH)2, CuSO4·5H2O, SO4^2- or ¹³CH4.",
+		render:      massView,
+	},
+	{
+		name:        "Balance",
+		prompt:      "Equation: ",
+		placeholder: "Fe + O2 -> Fe2O3",
+		hint:        "Type an equation such as Fe + O2 -> Fe2O3 or MnO4- + Fe^2+ + H+ -> Mn^2+ + Fe^3+ + H2O.",
+		render:      balanceView,
+	},
+	{
+		name:        "Stoichiometry",
+		prompt:      "Reaction: ",
+		placeholder: "Fe + O2 -> Fe2O3 | Fe=10g O2=5L",
+		hint:        "Type an equation, then | and amounts in g, mol or L of gas at STP, such as Fe + O2 -> Fe2O3 | Fe=10g O2=5L Fe2O3=12g. Amounts of products are actual yields.",
+		render:      stoichiometryView,
+	},
+	{
+		name:        "VSEPR",
+		prompt:      "Molecule: ",
+		placeholder: "SF4",
+		hint:        "Type a molecule or ion with a central atom, such as CH4, H2O, SF4, XeF4, NO3- or SO4^2-, for its shape.",
+		render:      vseprView,
+	},
+}
+
+type model struct {
+	data []element.Data
+	// element is the element selected in the table, selected again on the
+	// way back.
+	element  element.Data
+	tool     int
+	inputs   []textinput.Model
+	result   string
+	err      error
+	viewport viewport.Model
+	keys     keys.CalculatorKeyMap
+	help     help.Model
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.viewport = viewport.New(msg.Width, msg.Height-headerHeight)
+		m.help.Width = msg.Width
+	case views.OpenCalculatorMsg:
+		m.element = msg.Element
+		if msg.Formula != "" {
+			m.inputs[0].SetValue(msg.Formula)
+			cmds = append(cmds, m.selectTool(0))
+		} else {
+			cmds = append(cmds, m.inputs[m.tool].Focus())
+		}
+	case views.ConfigMsg:
+		m.keys = keys.CreateCalculatorKeys()
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Back):
+			m.inputs[m.tool].Blur()
+			return m, views.Open(views.OpenTableMsg{Element: m.element})
+		case key.Matches(msg, m.keys.NextTab):
+			cmds = append(cmds, m.selectTool((m.tool+1)%len(tools)))
+		case key.Matches(msg, m.keys.PrevTab):
+			cmds = append(cmds, m.selectTool((m.tool-1+len(tools))%len(tools)))
+		case key.Matches(msg, m.keys.Up):
+			m.viewport.LineUp(1)
+		case key.Matches(msg, m.keys.Down):
+			m.viewport.LineDown(1)
+		default:
+			m.inputs[m.tool], cmd = m.inputs[m.tool].Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	default:
+		m.inputs[m.tool], cmd = m.inputs[m.tool].Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	m.calculate()
+	m.viewport.SetContent(m.result)
+	return m, tea.Batch(cmds...)
+}
+
+// selectTool moves the focus to the input of tool i.
+func (m *model) selectTool(i int) tea.Cmd {
+	m.inputs[m.tool].Blur()
+	m.tool = i
+	m.viewport.GotoTop()
+	return m.inputs[m.tool].Focus()
+}
+
+// calculate updates the result for the input of the current tool.
+func (m *model) calculate() {
+	t := tools[m.tool]
+	input := m.inputs[m.tool].Value()
+	m.result, m.err = "", nil
+	if strings.TrimSpace(input) == "" {
+		m.result = hintStyle.Render(t.hint)
+		return
+	}
+	m.result, m.err = t.render(input, m.data)
+}
+
+func (m model) View() string {
+	helpView := m.help.View(m.keys)
+	// The viewport is sized for a one line help bar.
+	m.viewport.Height -= lipgloss.Height(helpView) - 1
+	return lipgloss.JoinVertical(0, m.tabsView(), "", m.inputs[m.tool].View(), m.errorView(), "", m.viewport.View(), helpView)
+}
+
+func (m model) tabsView() string {
+	var names []string
+	for i, t := range tools {
+		if i == m.tool {
+			names = append(names, activeTabStyle.Render(t.name))
+		} else {
+			names = append(names, tabStyle.Render(t.name))
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// errorView returns the error of the input, with a caret under the
+// offending position if it has one.
+func (m model) errorView() string {
+	if m.err == nil {
+		return ""
+	}
+	var formulaErr *formula.Error
+	if !errors.As(m.err, &formulaErr) {
+		return errorStyle().Render(m.err.Error())
+	}
+	input := []rune(m.inputs[m.tool].Value())
+	pos := formulaErr.Pos
+	if pos > len(input) {
+		pos = len(input)
+	}
+	indent := lipgloss.Width(m.inputs[m.tool].Prompt) + lipgloss.Width(string(input[:pos]))
+	return strings.Repeat(" ", indent) + errorStyle().Render("^ "+formulaErr.Msg)
+}
+
+func CreateModel(data []element.Data) tea.Model {
+	m := model{
+		data: data,
+		keys: keys.CreateCalculatorKeys(),
+		help: help.New(),
+	}
+	for _, t := range tools {
+		input := textinput.New()
+		input.Prompt = t.prompt
+		input.Placeholder = t.placeholder
+		m.inputs = append(m.inputs, input)
+	}
+	return m
+}
@@ -0,0 +1,65 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"periodic-table/src/formula"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/theme"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// barWidth is the width of the bar of an element making up all of the mass.
+const barWidth = 30
+
+// massView shows the molar mass of a formula and the share of each element
+// in it.
+func massView(input string, data []element.Data) (string, error) {
+	f, err := formula.Parse(input, data)
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{
+		labelStyle.Render("Formula     ") + f.String(),
+	}
+	if f.Charge != 0 {
+		lines = append(lines, labelStyle.Render("Charge      ")+fmt.Sprintf("%+d", f.Charge))
+	}
+	lines = append(lines,
+		labelStyle.Render("Molar mass  ")+valueStyle.Render(fmt.Sprintf("%.3f g/mol", f.MolarMass())),
+		"",
+		headerStyle.Render(fmt.Sprintf("%-16s %6s %12s %10s %8s", "Element", "Count", "Atomic mass", "Mass", "Percent")),
+	)
+	for _, s := range f.Composition() {
+		name := padRight(s.Symbol()+" "+s.Element.Element, 16)
+		row := fmt.Sprintf("%s %6d %12.3f %10.3f %7.2f%%", name, s.Count, s.Atom.Mass(), s.Mass, s.Percent)
+		lines = append(lines, row+"  "+bar(s.Percent, element.TypeColor(s.Element.Type)))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// bar draws percent as a horizontal bar, in eighths of a cell, or in hashes
+// in the plain profile.
+func bar(percent float64, color lipgloss.TerminalColor) string {
+	eighths := int(math.Round(percent / 100 * barWidth * 8))
+	if theme.IsPlain() {
+		return strings.Repeat("#", (eighths+4)/8)
+	}
+	b := strings.Repeat("█", eighths/8)
+	if rest := eighths % 8; rest > 0 {
+		b += string([]rune("▏▎▍▌▋▊▉")[rest-1])
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(b)
+}
+
+// padRight pads s with spaces to width cells, which Printf can't do for
+// superscripts.
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
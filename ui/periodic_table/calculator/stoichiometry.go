@@ -0,0 +1,69 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"periodic-table/src/formula"
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+)
+
+// stoichiometryView solves a reaction for the amounts typed after a "|",
+// such as "Fe + O2 -> Fe2O3 | Fe=10g O2=5L". Amounts of products are actual
+// yields.
+func stoichiometryView(input string, data []element.Data) (string, error) {
+	equation, amounts, hasAmounts := strings.Cut(input, "|")
+	e, err := formula.ParseEquation(equation, data)
+	if err != nil {
+		return "", err
+	}
+	if e, err = e.Balance(); err != nil {
+		return "", err
+	}
+	if !hasAmounts || strings.TrimSpace(amounts) == "" {
+		return valueStyle.Render(e.String()) + "\n\n" + hintStyle.Render("Add | and the amounts of reactants in g, mol or L of gas at STP, such as | Fe=10g O2=5L."), nil
+	}
+
+	quantities, err := formula.ParseQuantities(amounts, e)
+	var formulaErr *formula.Error
+	if errors.As(err, &formulaErr) {
+		offset := len([]rune(equation)) + 1
+		return "", &formula.Error{Source: input, Pos: offset + formulaErr.Pos, Msg: formulaErr.Msg}
+	} else if err != nil {
+		return "", err
+	}
+	st, err := e.Solve(quantities)
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{
+		labelStyle.Render("Reaction  ") + valueStyle.Render(e.String()),
+		labelStyle.Render("Limiting  ") + st.Reactants[st.Limiting].Written(),
+		"",
+		headerStyle.Render(fmt.Sprintf("%-10s %12s %12s %10s %12s", "Reactant", "Given", "Reacted", "Moles", "Left over")),
+	}
+	for i, r := range st.Reactants {
+		given, left := "—", "—"
+		if r.Given != nil {
+			given = r.Given.String()
+			left = fmt.Sprintf("%.3f g", r.Grams(r.Left))
+		}
+		row := fmt.Sprintf("%s %12s %12s %10.4f %12s", padRight(r.Written(), 10), given, fmt.Sprintf("%.3f g", r.Grams(r.Moles)), r.Moles, left)
+		if i == st.Limiting {
+			row = valueStyle.Render(row) + labelStyle.Render("  limiting")
+		}
+		lines = append(lines, row)
+	}
+
+	lines = append(lines, "", headerStyle.Render(fmt.Sprintf("%-10s %12s %12s %10s %12s", "Product", "Actual", "Theoretical", "Moles", "Yield")))
+	for _, r := range st.Products {
+		actual, percent := "—", "—"
+		if r.Given != nil {
+			actual = r.Given.String()
+			percent = fmt.Sprintf("%.1f%%", r.Percent)
+		}
+		lines = append(lines, fmt.Sprintf("%s %12s %12s %10.4f %12s", padRight(r.Written(), 10), actual, fmt.Sprintf("%.3f g", r.Grams(r.Moles)), r.Moles, percent))
+	}
+	return strings.Join(lines, "\n"), nil
+}
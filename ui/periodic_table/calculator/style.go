@@ -0,0 +1,20 @@
+package calculator
+
+import (
+	"periodic-table/ui/periodic_table/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	tabStyle       = lipgloss.NewStyle().Padding(0, 1).Faint(true)
+	activeTabStyle = lipgloss.NewStyle().Padding(0, 1).Bold(true).Reverse(true)
+	headerStyle    = lipgloss.NewStyle().Bold(true)
+	labelStyle     = lipgloss.NewStyle().Faint(true)
+	valueStyle     = lipgloss.NewStyle().Bold(true)
+	hintStyle      = lipgloss.NewStyle().Faint(true).Italic(true)
+)
+
+func errorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Current().Error)
+}
@@ -0,0 +1,45 @@
+package calculator
+
+import (
+	"fmt"
+	"periodic-table/src/formula"
+	"periodic-table/src/vsepr"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/theme"
+	"strings"
+)
+
+// vseprView predicts the shape of a molecule or ion and sketches it.
+func vseprView(input string, data []element.Data) (string, error) {
+	f, err := formula.Parse(input, data)
+	if err != nil {
+		return "", err
+	}
+	m, err := vsepr.Predict(f)
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{
+		labelStyle.Render("Formula             ") + m.Formula.Written(),
+		labelStyle.Render("Central atom        ") + m.Central.Symbol(),
+		labelStyle.Render("AXE                 ") + valueStyle.Render(m.AXE()),
+		labelStyle.Render("Electron domains    ") + fmt.Sprintf("%d: %s", m.Domains(), m.Summary()),
+	}
+	if m.OddElectron {
+		lines = append(lines, labelStyle.Render("Odd electron        ")+"on "+m.Central.Symbol()+", counted as a lone pair")
+	}
+	angles := m.BondAngles
+	if theme.IsPlain() {
+		angles = strings.ReplaceAll(angles, "°", " deg")
+	}
+	lines = append(lines,
+		labelStyle.Render("Electron geometry   ")+m.ElectronGeometry,
+		labelStyle.Render("Molecular geometry  ")+valueStyle.Render(m.MolecularGeometry),
+		labelStyle.Render("Bond angles         ")+angles,
+		labelStyle.Render("Hybridization       ")+m.Hybridization,
+		"",
+		m.Sketch(theme.IsPlain()),
+	)
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,90 @@
+// Package compare lines up the properties of pinned elements side by side.
+package compare
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/views"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type model struct {
+	elements  []element.Data
+	reference int
+	viewport  viewport.Model
+	keys      keys.CompareKeyMap
+	help      help.Model
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.viewport = viewport.New(msg.Width, msg.Height-1)
+		m.help.Width = msg.Width
+	case views.OpenCompareMsg:
+		m.elements = msg.Elements
+		m.reference = 0
+		m.viewport.GotoTop()
+	case views.ConfigMsg:
+		m.keys = keys.CreateCompareKeys()
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+		case key.Matches(msg, m.keys.Back):
+			return m, views.Open(views.OpenTableMsg{})
+		case key.Matches(msg, m.keys.PrevReference):
+			if len(m.elements) == 0 {
+				break
+			}
+			m.reference = (m.reference - 1 + len(m.elements)) % len(m.elements)
+		case key.Matches(msg, m.keys.NextReference):
+			if len(m.elements) == 0 {
+				break
+			}
+			m.reference = (m.reference + 1) % len(m.elements)
+		case key.Matches(msg, m.keys.Up):
+			m.viewport.LineUp(1)
+		case key.Matches(msg, m.keys.Down):
+			m.viewport.LineDown(1)
+		}
+	}
+
+	m.viewport.SetContent(m.content())
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m model) View() string {
+	helpView := m.help.View(m.keys)
+	// The viewport is sized for a one line help bar.
+	m.viewport.Height -= lipgloss.Height(helpView) - 1
+	return lipgloss.JoinVertical(0, m.viewport.View(), helpView)
+}
+
+func (m model) content() string {
+	if len(m.elements) == 0 {
+		return "No elements pinned. Pin elements in the table with p."
+	}
+	return lipgloss.JoinVertical(0, m.tableView(), "", m.comparisonsView())
+}
+
+func CreateModel() tea.Model {
+	return model{
+		keys: keys.CreateCompareKeys(),
+		help: help.New(),
+	}
+}
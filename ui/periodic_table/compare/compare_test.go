@@ -0,0 +1,17 @@
+package compare
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestUpdate_NoElements(t *testing.T) {
+	m := CreateModel()
+	for _, k := range []string{"h", "l"} {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+	if got := m.(model).reference; got != 0 {
+		t.Errorf("reference = %d, want 0", got)
+	}
+}
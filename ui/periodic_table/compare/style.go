@@ -0,0 +1,22 @@
+package compare
+
+import (
+	"periodic-table/ui/periodic_table/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	headerStyle    = lipgloss.NewStyle().Bold(true)
+	referenceStyle = headerStyle.Copy().Underline(true)
+	labelStyle     = lipgloss.NewStyle().Faint(true)
+	missingStyle   = lipgloss.NewStyle().Faint(true)
+)
+
+func highestStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Current().Highest).Bold(true)
+}
+
+func lowestStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Current().Lowest)
+}
@@ -0,0 +1,212 @@
+package compare
+
+import (
+	"fmt"
+	"math"
+	"periodic-table/ui/periodic_table/element"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	labelWidth  = 30
+	columnWidth = 16
+)
+
+// tableView shows one row per property and one column per element, marking
+// the largest and smallest value of each numeric row.
+func (m model) tableView() string {
+	header := cell("", labelWidth)
+	for i, d := range m.elements {
+		style := headerStyle
+		if i == m.reference {
+			style = referenceStyle
+		}
+		header += style.Render(cell(d.Symbol+" "+d.Element, columnWidth))
+	}
+
+	lines := []string{header}
+	for _, field := range element.Fields {
+		if field.Name == "name" || field.Name == "symbol" {
+			continue
+		}
+
+		lowest, highest := extremes(field, m.elements)
+		line := labelStyle.Render(cell(label(field), labelWidth))
+		for i, d := range m.elements {
+			value := field.Text(d)
+			text := cell(value, columnWidth)
+			switch {
+			case value == "":
+				text = missingStyle.Render(cell("—", columnWidth))
+			case lowest != highest && i == highest:
+				text = highestStyle().Render(text)
+			case lowest != highest && i == lowest:
+				text = lowestStyle().Render(text)
+			}
+			line += text
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// comparisonsView describes how every element relates to the reference
+// element, e.g. "Fe is 2.9× denser than Al".
+func (m model) comparisonsView() string {
+	if len(m.elements) < 2 {
+		return "Pin another element to compare against " + m.elements[0].Element + "."
+	}
+
+	reference := m.elements[m.reference]
+	lines := []string{headerStyle.Render("Compared with " + reference.Element)}
+	for i, d := range m.elements {
+		if i == m.reference {
+			continue
+		}
+		for _, field := range element.Fields {
+			if sentence, ok := compare(field, d, reference); ok {
+				lines = append(lines, "  "+sentence)
+			}
+		}
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// comparatives phrase ratios for properties where plain English has a word
+// for "more" and "less".
+var comparatives = map[string][2]string{
+	"atomic_mass":   {"heavier", "lighter"},
+	"density":       {"denser", "less dense"},
+	"atomic_radius": {"larger", "smaller"},
+}
+
+var comparedFields = []string{
+	"atomic_mass",
+	"atomic_radius",
+	"electronegativity",
+	"first_ionization",
+	"density",
+	"melting_point",
+	"boiling_point",
+	"specific_heat",
+}
+
+func compare(field element.Field, d element.Data, reference element.Data) (string, bool) {
+	if !contains(comparedFields, field.Name) {
+		return "", false
+	}
+
+	value, ok := field.Float(d)
+	referenceValue, referenceOk := field.Float(reference)
+	if !ok || !referenceOk {
+		return "", false
+	}
+
+	difference := formatNumber(field.Convert(value)-field.Convert(referenceValue)) + " " + field.DisplayUnit()
+	if value-referenceValue > 0 {
+		difference = "+" + difference
+	}
+	difference = strings.TrimSpace(difference)
+
+	if value == referenceValue {
+		return fmt.Sprintf("%s has the same %s as %s", d.Element, strings.ToLower(field.Label), reference.Element), true
+	}
+	if value <= 0 || referenceValue <= 0 {
+		return fmt.Sprintf("%s's %s differs from %s's by %s", d.Element, strings.ToLower(field.Label), reference.Element, difference), true
+	}
+
+	more := value > referenceValue
+	ratio := value / referenceValue
+	if !more {
+		ratio = referenceValue / value
+	}
+
+	if words, ok := comparatives[field.Name]; ok {
+		word := words[0]
+		if !more {
+			word = words[1]
+		}
+		return fmt.Sprintf("%s is %s× %s than %s (%s)", d.Element, formatNumber(ratio), word, reference.Element, difference), true
+	}
+
+	direction := "higher"
+	if !more {
+		direction = "lower"
+	}
+	return fmt.Sprintf("%s's %s is %s× %s than %s's (%s)", d.Element, strings.ToLower(field.Label), formatNumber(ratio), direction, reference.Element, difference), true
+}
+
+// extremes returns the positions of the smallest and largest value of a
+// numeric field, or -1 if the field isn't numeric.
+func extremes(field element.Field, elements []element.Data) (int, int) {
+	if field.Kind != element.Number {
+		return -1, -1
+	}
+
+	lowest, highest := -1, -1
+	min, max := math.Inf(1), math.Inf(-1)
+	for i, d := range elements {
+		value, ok := field.Float(d)
+		if !ok {
+			continue
+		}
+		if value < min {
+			min, lowest = value, i
+		}
+		if value > max {
+			max, highest = value, i
+		}
+	}
+	return lowest, highest
+}
+
+func label(field element.Field) string {
+	if field.DisplayUnit() == "" {
+		return field.Label
+	}
+	return field.Label + " (" + field.DisplayUnit() + ")"
+}
+
+func cell(text string, width int) string {
+	if lipgloss.Width(text) >= width {
+		runes := []rune(text)
+		text = string(runes[:width-2]) + "…"
+	}
+	return text + strings.Repeat(" ", width-lipgloss.Width(text))
+}
+
+// formatNumber rounds value to three significant digits without switching to
+// exponent notation for everyday magnitudes.
+func formatNumber(value float64) string {
+	if value == 0 {
+		return "0"
+	}
+
+	magnitude := int(math.Floor(math.Log10(math.Abs(value))))
+	if magnitude < -4 || magnitude > 8 {
+		return strconv.FormatFloat(value, 'g', 3, 64)
+	}
+
+	decimals := 2 - magnitude
+	if decimals < 0 {
+		decimals = 0
+	}
+	text := strconv.FormatFloat(value, 'f', decimals, 64)
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+	return text
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package compare
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	iron := element.Data{Element: "Iron", AtomicMass: "55.845", Density: "7.874", Electronegativity: "1.83", MeltingPoint: "1811", AtomicRadius: "1.7"}
+	aluminum := element.Data{Element: "Aluminum", AtomicMass: "26.982", Density: "2.7", Electronegativity: "1.61", MeltingPoint: "933.47", AtomicRadius: "1.8"}
+	cobalt := element.Data{Element: "Cobalt", Density: "7.874", Electronegativity: "0"}
+
+	tests := []struct {
+		field    string
+		d, ref   element.Data
+		want     string
+		compared bool
+	}{
+		{"density", iron, aluminum, "Iron is 2.92× denser than Aluminum (+5.17 g/cm³)", true},
+		{"density", aluminum, iron, "Aluminum is 2.92× less dense than Iron (-5.17 g/cm³)", true},
+		{"atomic_mass", iron, aluminum, "Iron is 2.07× heavier than Aluminum (+28.9 u)", true},
+		{"atomic_radius", iron, aluminum, "Iron is 1.06× smaller than Aluminum (-0.1 Å)", true},
+		{"melting_point", iron, aluminum, "Iron's melting point is 1.94× higher than Aluminum's (+878 K)", true},
+		{"electronegativity", aluminum, iron, "Aluminum's electronegativity is 1.14× lower than Iron's (-0.22 Pauling)", true},
+		{"density", cobalt, iron, "Cobalt has the same density as Iron", true},
+		{"electronegativity", cobalt, iron, "Cobalt's electronegativity differs from Iron's by -1.83 Pauling", true},
+		{"melting_point", cobalt, iron, "", false},
+		{"atomic_number", iron, aluminum, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field+"/"+tt.d.Element, func(t *testing.T) {
+			field, _ := element.FieldByName(tt.field)
+			got, ok := compare(field, tt.d, tt.ref)
+			if got != tt.want || ok != tt.compared {
+				t.Errorf("compare() = %q, %v, want %q, %v", got, ok, tt.want, tt.compared)
+			}
+		})
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{2.918148, "2.92"},
+		{-5.174, "-5.17"},
+		{28.863, "28.9"},
+		{877.53, "878"},
+		{123456, "123456"},
+		{0.000179, "0.000179"},
+		{0.00001234, "1.23e-05"},
+		{3.2e10, "3.2e+10"},
+		{1.5, "1.5"},
+	}
+	for _, tt := range tests {
+		if got := formatNumber(tt.value); got != tt.want {
+			t.Errorf("formatNumber(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package detail
+
+import (
+	"fmt"
+	"math"
+	"periodic-table/src/electron"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/theme"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bohrView draws the element as a Bohr model, its electrons on concentric
+// shells around the nucleus with the outer shell highlighted, above its
+// electron configuration and, for main-group elements, its Lewis symbol.
+// The shells are drawn closer together to fit the page, and listed on one
+// line if they can't fit.
+func bohrView(p page) string {
+	d := p.element
+	z, _ := strconv.Atoi(d.AtomicNumber)
+	c, err := electron.Configure(z)
+	if err != nil {
+		return labelled("Configuration", missingStyle.Render("unknown"))
+	}
+
+	shells := c.Shells()
+	var counts []string
+	for i, n := range shells {
+		counts = append(counts, fmt.Sprintf("%c %d", electron.ShellNames[i], n))
+	}
+	outer := fmt.Sprintf("%d electrons", shells[len(shells)-1])
+	if shells[len(shells)-1] == 1 {
+		outer = "1 electron"
+	}
+	lines := []string{
+		labelled("Configuration", c.Condensed()),
+		labelled("Shells", strings.Join(counts, separator())),
+		labelled("Outer shell", outer),
+	}
+	if lewis, ok := lewisSymbol(d); ok {
+		lines = append(lines, labelled("Lewis symbol", lewis[0]))
+		for _, line := range lewis[1:] {
+			lines = append(lines, strings.Repeat(" ", labelWidth)+line)
+		}
+	}
+	info := strings.Join(lines, "\n")
+
+	diagram := bohrDiagram(d, shells, p.width, p.height-lipgloss.Height(info)-1)
+	return lipgloss.JoinVertical(0, diagram, "", info)
+}
+
+// bohrDiagram draws the shells as ellipses twice as wide as they are high,
+// which look round in most terminal fonts, spaced by three lines or two if
+// that doesn't fit in width and height. Each shell is labeled with its count
+// where it crosses the axis on the right, which leaves no room for closer
+// shells.
+func bohrDiagram(d element.Data, shells []int, width, height int) string {
+	for spacing := 3; spacing >= 2; spacing-- {
+		if diagram, ok := drawBohr(d, shells, spacing); ok && lipgloss.Width(diagram) <= width && lipgloss.Height(diagram) <= height {
+			return diagram
+		}
+	}
+	return compactBohr(d, shells)
+}
+
+// drawBohr draws the diagram with spacing lines between shells. It reports
+// false if a shell has too many electrons to be drawn apart.
+func drawBohr(d element.Data, shells []int, spacing int) (string, bool) {
+	outer := len(shells) * spacing
+	cx, cy := 2*outer+1, outer
+	canvas := make([][]string, 2*outer+1)
+	for y := range canvas {
+		canvas[y] = make([]string, 2*cx+3)
+		for x := range canvas[y] {
+			canvas[y][x] = " "
+		}
+	}
+
+	ring, electronMark, valenceMark := "·", "●", "●"
+	if theme.IsPlain() {
+		ring, electronMark, valenceMark = ".", "o", "@"
+	}
+	color := element.TypeColor(d.Type)
+	valenceStyle := lipgloss.NewStyle().Bold(true).Foreground(color)
+
+	for i, n := range shells {
+		ry := (i + 1) * spacing
+		rx := 2*ry + 1
+		label := strconv.Itoa(n)
+		points := ellipse(cx, cy, rx, ry, len(label))
+		if 3*n > 2*len(points) {
+			return "", false
+		}
+		for _, p := range points {
+			canvas[p[1]][p[0]] = ringStyle.Render(ring)
+		}
+		mark := electronMark
+		if i == len(shells)-1 {
+			mark = valenceStyle.Render(valenceMark)
+		}
+		for e := 0; e < n; e++ {
+			p := points[e*len(points)/n]
+			canvas[p[1]][p[0]] = mark
+		}
+		for j, r := range label {
+			canvas[cy][cx+rx+j] = labelStyle.Render(string(r))
+		}
+	}
+
+	symbol := []rune(strings.TrimSpace(d.Symbol))
+	start := cx - (len(symbol)-1)/2
+	for j, r := range symbol {
+		canvas[cy][start+j] = valenceStyle.Render(string(r))
+	}
+
+	lines := make([]string, len(canvas))
+	for y, row := range canvas {
+		lines[y] = strings.TrimRight(strings.Join(row, ""), " ")
+	}
+	return strings.Join(lines, "\n"), true
+}
+
+// ellipse returns the cells of an ellipse centered on cx, cy clockwise from
+// its top, leaving out labelWidth cells where it crosses the axis on the
+// right for the label.
+func ellipse(cx, cy, rx, ry, labelWidth int) [][2]int {
+	var points [][2]int
+	seen := map[[2]int]bool{}
+	steps := 16 * (rx + ry)
+	for i := 0; i < steps; i++ {
+		t := math.Pi/2 - 2*math.Pi*float64(i)/float64(steps)
+		p := [2]int{cx + int(math.Round(float64(rx)*math.Cos(t))), cy - int(math.Round(float64(ry)*math.Sin(t)))}
+		if seen[p] || (p[1] == cy && p[0] >= cx+rx && p[0] < cx+rx+labelWidth) {
+			continue
+		}
+		seen[p] = true
+		points = append(points, p)
+	}
+	return points
+}
+
+// compactBohr writes the shells on one line, as textbooks do for large
+// atoms: "Fe 2) 8) 14) 2)".
+func compactBohr(d element.Data, shells []int) string {
+	style := lipgloss.NewStyle().Bold(true).Foreground(element.TypeColor(d.Type))
+	parts := []string{style.Render(strings.TrimSpace(d.Symbol))}
+	for i, n := range shells {
+		count := strconv.Itoa(n) + ")"
+		if i == len(shells)-1 {
+			count = style.Render(count)
+		}
+		parts = append(parts, count)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,133 @@
+// Package detail shows every property of a single element on a full-screen
+// page split into tabs.
+package detail
+
+import (
+	"fmt"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/theme"
+	"periodic-table/ui/periodic_table/views"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// headerHeight is the number of lines above and below the scrollable page.
+const headerHeight = 6
+
+// minCharge is the charge of the most negative ion shown, as in C⁴⁻.
+const minCharge = -4
+
+type model struct {
+	element element.Data
+	// charge is the charge of the ion shown in ion tabs.
+	charge   int
+	tab      int
+	viewport viewport.Model
+	keys     keys.DetailKeyMap
+	help     help.Model
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.viewport = viewport.New(msg.Width, msg.Height-headerHeight)
+		m.help.Width = msg.Width
+	case views.OpenElementMsg:
+		m.element = msg.Element
+		m.charge = 0
+		m.viewport.GotoTop()
+	case views.ConfigMsg:
+		m.keys = keys.CreateDetailKeys()
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+		case key.Matches(msg, m.keys.Back):
+			return m, views.Open(views.OpenTableMsg{Element: m.element})
+		case key.Matches(msg, m.keys.NextTab):
+			m.tab = (m.tab + 1) % len(tabs)
+			m.viewport.GotoTop()
+		case key.Matches(msg, m.keys.PrevTab):
+			m.tab = (m.tab - 1 + len(tabs)) % len(tabs)
+			m.viewport.GotoTop()
+		case key.Matches(msg, m.keys.RaiseCharge) && tabs[m.tab].ion:
+			if z, _ := strconv.Atoi(m.element.AtomicNumber); m.charge < z {
+				m.charge++
+			}
+		case key.Matches(msg, m.keys.LowerCharge) && tabs[m.tab].ion:
+			if m.charge > minCharge {
+				m.charge--
+			}
+		case key.Matches(msg, m.keys.Up):
+			m.viewport.LineUp(1)
+		case key.Matches(msg, m.keys.Down):
+			m.viewport.LineDown(1)
+		}
+	}
+
+	m.viewport.SetContent(tabs[m.tab].render(page{
+		element: m.element,
+		charge:  m.charge,
+		width:   m.viewport.Width,
+		height:  m.viewport.Height,
+	}))
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m model) View() string {
+	helpView := m.help.View(m.keys)
+	// The viewport is sized for a one line help bar.
+	m.viewport.Height -= lipgloss.Height(helpView) - 1
+	return lipgloss.JoinVertical(0, m.headingView(), m.tabsView(), "", m.viewport.View(), helpView)
+}
+
+func (m model) headingView() string {
+	color := element.TypeColor(m.element.Type)
+	symbol := symbolStyle.Copy().BorderStyle(theme.Border(lipgloss.RoundedBorder())).BorderForeground(color).Render(m.element.Symbol)
+	name := fmt.Sprintf("%s\nAtomic number %s%s%s", m.element.Element, m.element.AtomicNumber, separator(), m.element.Type)
+	return lipgloss.JoinHorizontal(lipgloss.Center, symbol, " ", nameStyle.Render(name))
+}
+
+// separator returns the dot between the parts of a line, or a dash in the
+// plain profile.
+func separator() string {
+	if theme.IsPlain() {
+		return " - "
+	}
+	return " · "
+}
+
+func (m model) tabsView() string {
+	var names []string
+	for i, t := range tabs {
+		if i == m.tab {
+			names = append(names, activeTabStyle.Render(t.name))
+		} else {
+			names = append(names, tabStyle.Render(t.name))
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+func CreateModel() tea.Model {
+	return model{
+		keys: keys.CreateDetailKeys(),
+		help: help.New(),
+	}
+}
@@ -0,0 +1,38 @@
+package detail
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/theme"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lewisSymbol draws the valence electrons of a main-group element as dots
+// around its symbol, on three lines. Each side takes one dot, in the order
+// top, right, bottom and left, before any takes a second. It reports false
+// for the other elements.
+func lewisSymbol(d element.Data) ([]string, bool) {
+	valence, ok := d.Valence()
+	if !ok {
+		return nil, false
+	}
+
+	var sides [4]int
+	for i := 0; i < valence; i++ {
+		sides[i%4]++
+	}
+	dot, pair, column := "·", "··", ":"
+	if theme.IsPlain() {
+		dot, pair = ".", ".."
+	}
+	across := func(n int) string { return []string{"", dot, pair}[n] }
+	down := func(n int) string { return []string{" ", dot, column}[n] }
+
+	symbol := lipgloss.NewStyle().Bold(true).Foreground(element.TypeColor(d.Type)).Render(strings.TrimSpace(d.Symbol))
+	return []string{
+		" " + across(sides[0]),
+		down(sides[3]) + symbol + down(sides[1]),
+		" " + across(sides[2]),
+	}, true
+}
@@ -0,0 +1,72 @@
+package detail
+
+import (
+	"fmt"
+	"periodic-table/src/electron"
+	"periodic-table/src/formula"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/theme"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// orbitalsView draws the orbitals of the element, or of its ion, as boxes
+// filled by Hund's rule, under the ground state term and magnetism they
+// lead to.
+func orbitalsView(p page) string {
+	d := p.element
+	z, _ := strconv.Atoi(d.AtomicNumber)
+	c, err := electron.Ion(z, p.charge)
+	if err != nil {
+		return labelled("Configuration", missingStyle.Render("unknown"))
+	}
+
+	species, name := "Atom", strings.TrimSpace(d.Symbol)
+	if p.charge != 0 {
+		species, name = "Ion", name+formula.ChargeString(p.charge)
+	}
+	magnetism := "diamagnetic"
+	if c.Paramagnetic() {
+		magnetism = "paramagnetic"
+	}
+	configuration := c.Condensed()
+	if len(c) == 0 {
+		configuration = "no electrons"
+	}
+	lines := []string{
+		labelled(species, name),
+		labelled("Configuration", configuration),
+		labelled("Unpaired electrons", strconv.Itoa(c.Unpaired())),
+		labelled("Term symbol", c.TermSymbol()),
+		labelled("Magnetism", magnetism),
+		"",
+	}
+
+	unpaired := lipgloss.NewStyle().Bold(true).Foreground(element.TypeColor(d.Type))
+	for _, s := range c {
+		var boxes strings.Builder
+		for _, n := range s.Orbitals() {
+			boxes.WriteString(box(n, unpaired))
+		}
+		lines = append(lines, fmt.Sprintf("%-4s%s", s.Name(), boxes.String()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// box draws an orbital holding n electrons, as "[↑↓]", "[↑ ]" or "[  ]".
+// A lone electron is drawn in style.
+func box(n int, style lipgloss.Style) string {
+	up, down := "↑", "↓"
+	if theme.IsPlain() {
+		up, down = "^", "v"
+	}
+	switch n {
+	case 2:
+		return "[" + up + down + "]"
+	case 1:
+		return "[" + style.Render(up) + " ]"
+	}
+	return "[  ]"
+}
@@ -0,0 +1,13 @@
+package detail
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	symbolStyle    = lipgloss.NewStyle().BorderStyle(lipgloss.RoundedBorder()).Padding(0, 1).Bold(true)
+	nameStyle      = lipgloss.NewStyle().Bold(true)
+	tabStyle       = lipgloss.NewStyle().Padding(0, 1).Faint(true)
+	activeTabStyle = lipgloss.NewStyle().Padding(0, 1).Bold(true).Reverse(true)
+	labelStyle     = lipgloss.NewStyle().Faint(true)
+	missingStyle   = lipgloss.NewStyle().Faint(true).Italic(true)
+	ringStyle      = lipgloss.NewStyle().Faint(true)
+)
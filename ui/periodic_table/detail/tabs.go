@@ -0,0 +1,76 @@
+package detail
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+)
+
+// tab is one page of the detail view.
+type tab struct {
+	name   string
+	render func(p page) string
+	// ion is set for tabs which show the element as an ion, whose charge
+	// is changed with keys.
+	ion bool
+}
+
+// page is what a tab is rendered from. It should fit width and height, and
+// is scrolled if it doesn't.
+type page struct {
+	element       element.Data
+	charge        int
+	width, height int
+}
+
+var tabs = []tab{
+	{name: "Overview", render: fieldList("name", "symbol", "atomic_number", "atomic_mass", "type", "block", "period", "group", "phase")},
+	{name: "Atomic", render: fieldList("protons", "electrons", "shells", "valence", "atomic_radius", "electronegativity", "first_ionization")},
+	{name: "Physical", render: fieldList("phase", "density", "melting_point", "boiling_point", "specific_heat", "metal", "nonmetal", "metalloid")},
+	{name: "Shells", render: bohrView},
+	{name: "Orbitals", render: orbitalsView, ion: true},
+	{name: "History", render: fieldList("discoverer", "year", "natural")},
+	{name: "Nuclear", render: fieldList("protons", "neutrons", "atomic_mass", "isotopes", "radioactive")},
+}
+
+const labelWidth = 20
+
+// fieldList renders the named fields as aligned label and value pairs.
+func fieldList(names ...string) func(p page) string {
+	return func(p page) string {
+		var lines []string
+		for _, name := range names {
+			field, ok := element.FieldByName(name)
+			if !ok {
+				continue
+			}
+
+			lines = append(lines, labelled(field.Label, value(field, p.element)))
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// labelled returns a line of a label and a value, aligned with the others.
+// Labels too long for the column are followed by a single space.
+func labelled(label, value string) string {
+	padding := labelWidth - len([]rune(label))
+	if padding < 1 {
+		padding = 1
+	}
+	return labelStyle.Render(label+strings.Repeat(" ", padding)) + value
+}
+
+func value(field element.Field, d element.Data) string {
+	value := field.Text(d)
+	switch {
+	case field.Kind == element.Flag && field.IsSet(d):
+		return "yes"
+	case field.Kind == element.Flag:
+		return "no"
+	case value == "":
+		return missingStyle.Render("unknown")
+	case field.DisplayUnit() != "":
+		return value + " " + field.DisplayUnit()
+	}
+	return value
+}
@@ -0,0 +1,22 @@
+package detail
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLabelled(t *testing.T) {
+	tests := []struct {
+		label     string
+		wantLabel string
+	}{
+		{"Density", "Density" + strings.Repeat(" ", labelWidth-len("Density"))},
+		{"Ionization energies of the atom", "Ionization energies of the atom "},
+	}
+	for _, tt := range tests {
+		want := labelStyle.Render(tt.wantLabel) + "7.87"
+		if got := labelled(tt.label, "7.87"); got != want {
+			t.Errorf("labelled(%q) = %q, want %q", tt.label, got, want)
+		}
+	}
+}
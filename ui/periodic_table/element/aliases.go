@@ -0,0 +1,41 @@
+package element
+
+import "strings"
+
+// aliases holds other names an element is known by, keyed by symbol. They are
+// matched when searching but never displayed.
+var aliases = map[string][]string{
+	"H":  {"Hydrogenium"},
+	"Na": {"Natrium"},
+	"K":  {"Kalium"},
+	"Al": {"Aluminium"},
+	"S":  {"Sulphur"},
+	"Fe": {"Ferrum"},
+	"Cu": {"Cuprum"},
+	"Ag": {"Argentum"},
+	"Sn": {"Stannum"},
+	"Sb": {"Stibium"},
+	"Cs": {"Caesium"},
+	"W":  {"Wolfram"},
+	"Au": {"Aurum"},
+	"Hg": {"Quicksilver", "Hydrargyrum"},
+	"Pb": {"Plumbum"},
+}
+
+func searchStrings(data Data) []string {
+	names := []string{data.Element, data.Symbol, data.AtomicNumber}
+	return append(names, aliases[data.Symbol]...)
+}
+
+// Find returns the element of data with the given name, symbol, atomic number
+// or alias, ignoring case.
+func Find(data []Data, key string) (Data, bool) {
+	for _, d := range data {
+		for _, name := range searchStrings(d) {
+			if strings.EqualFold(name, key) {
+				return d, true
+			}
+		}
+	}
+	return Data{}, false
+}
@@ -3,6 +3,9 @@ package element
 import (
 	"fmt"
 	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/theme"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -39,34 +42,83 @@ type Data struct {
 }
 
 func (d *Data) GetDataAsString() string {
-	text := fmt.Sprintf(`Type: %s
-Atomic number: %s
-Atomic mass: %s
-Electrons: %s
-Protons: %s
-Neutrons: %s
-Group: %s
-Density: %s
-Atomic Radius: %s
-Melting Point: %s
-Specific Heat: %s
-	`, d.Type, d.AtomicNumber, d.AtomicMass, d.NumberOfElectrons, d.NumberOfProtons, d.NumberOfNeutrons, d.Group, d.Density, d.AtomicRadius, d.MeltingPoint, d.SpecificHeat)
+	var lines []string
+	for _, name := range infoFields {
+		f, ok := FieldByName(name)
+		if !ok {
+			continue
+		}
+		value := f.Text(*d)
+		if value != "" && f.DisplayUnit() != "" {
+			value += " " + f.DisplayUnit()
+		}
+		lines = append(lines, f.Label+": "+value)
+	}
+	return strings.Join(lines, "\n")
+}
 
-	return text
+// Block returns the s, p, d or f block the element belongs to, or an empty
+// string if it cannot be worked out from the data.
+func (d *Data) Block() string {
+	if d.Type == "Lanthanide" || d.Type == "Actinide" {
+		return "f"
+	}
 
+	switch d.Group {
+	case "":
+		return ""
+	case "1", "2":
+		return "s"
+	case "13", "14", "15", "16", "17":
+		return "p"
+	case "18":
+		if d.Period == "1" {
+			return "s"
+		}
+		return "p"
+	}
+	return "d"
+}
+
+// Valence returns the number of valence electrons of a main-group element,
+// from NumberOfValence or from the group where the data has none, as for
+// helium. It reports false for the other elements, whose valence electrons
+// are not all in the outer shell.
+func (d *Data) Valence() (int, bool) {
+	if block := d.Block(); block != "s" && block != "p" {
+		return 0, false
+	}
+	if valence, err := strconv.Atoi(d.NumberOfValence); err == nil {
+		return valence, true
+	}
+	group, err := strconv.Atoi(d.Group)
+	switch {
+	case err != nil:
+		return 0, false
+	case d.Period == "1" && group == 18:
+		return 2, true
+	case group <= 2:
+		return group, true
+	}
+	return group - 10, true
 }
 
 type Element struct {
 	data            Data
 	selectedStyle   lipgloss.Style
 	unSelectedStyle lipgloss.Style
-	searchString    string
+	searchStrings   []string
 	isSelected      bool
+	isHighlighted   bool
+	isDimmed        bool
+	isMarked        bool
 	isPaddingCell   bool
+	// code stands in for the color of the cell in the plain profile.
+	code string
 }
 
-func (c *Element) GetSearchString() string {
-	return c.searchString
+func (c *Element) GetSearchStrings() []string {
+	return c.searchStrings
 }
 
 func (c *Element) GetData() interface{} {
@@ -74,12 +126,48 @@ func (c *Element) GetData() interface{} {
 }
 
 func (c *Element) GetView() string {
+	if c.isPaddingCell {
+		return emptyStyle().Render("")
+	}
+	if cellSize == Tiny {
+		return c.tinyView()
+	}
+
 	var text string
 	// Make cell text here
-	text = styleText(c.data.AtomicNumber, c.data.Symbol)
+	w := cellSize.contentWidth()
+	top, bottom := cellFields[0], cellFields[1]
+	number := truncate(fieldValue(top, c.data), w)
+	var suffix string
+	if c.isMarked {
+		suffix = marker
+	}
+	if theme.IsPlain() {
+		if c.isMarked {
+			suffix = asciiMarker
+		}
+		suffix += c.code
+	}
+	if n := lipgloss.Width(suffix); n > 0 {
+		// A cut number would be misread in narrower cells, so it makes way
+		// for the suffix instead.
+		if cellSize != Normal && lipgloss.Width(number) > w-n {
+			number = ""
+		}
+		number = fmt.Sprintf("%-*s%s", w-n, truncate(number, w-n), suffix)
+	}
+	symbol := truncate(fieldValue(bottom, c.data), w)
+	if c.isSelected && theme.IsPlain() {
+		symbol = "[" + truncate(symbol, w-2) + "]"
+	}
+	text = styleText(number, symbol)
 	// Put formatting/styling here
 	if c.isSelected {
 		text = c.selectedStyle.Render(text)
+	} else if c.isDimmed {
+		text = dimmedStyle().Render(text)
+	} else if c.isHighlighted {
+		text = c.unSelectedStyle.Copy().BorderStyle(border(highlightBorder, asciiHighlightBorder)).Render(text)
 	} else {
 		text = c.unSelectedStyle.Render(text)
 	}
@@ -87,6 +175,36 @@ func (c *Element) GetView() string {
 	return text
 }
 
+// tinyView draws the cell in a single line without a border, on the color
+// its border would have. Highlighted cells are bold and underlined, or
+// marked with an asterisk in the plain profile, which has no category codes
+// in tiny cells.
+func (c *Element) tinyView() string {
+	w := cellSize.Width()
+	text := " " + truncate(fieldValue(cellFields[1], c.data), w-1)
+	if theme.IsPlain() {
+		if c.isHighlighted {
+			text = asciiMarker + text[1:]
+		}
+		if c.isSelected {
+			return tinyStyle(nil).Reverse(true).Render(text)
+		}
+		return tinyStyle(nil).Render(text)
+	}
+
+	if c.isDimmed && !c.isSelected {
+		return lipgloss.NewStyle().Width(w).Foreground(theme.Current().DimmedText).Render(text)
+	}
+	style := tinyStyle(c.unSelectedStyle.GetBorderTopForeground())
+	if c.isHighlighted {
+		style = style.Bold(true).Underline(true)
+	}
+	if c.isSelected {
+		style = style.Reverse(true)
+	}
+	return style.Render(text)
+}
+
 func (c *Element) GetUnselectedStyle() lipgloss.Style {
 	return c.unSelectedStyle
 }
@@ -104,8 +222,26 @@ func (c *Element) SetSelected(isSelected bool) {
 	c.isSelected = isSelected
 }
 
+func (c *Element) SetHighlighted(isHighlighted bool) {
+	c.isHighlighted = isHighlighted
+}
+
+func (c *Element) SetDimmed(isDimmed bool) {
+	c.isDimmed = isDimmed
+}
+
+func (c *Element) SetMarked(isMarked bool) {
+	c.isMarked = isMarked
+}
+
+// SetCode sets the letter code of the cell's category, shown in the top
+// right of the cell in the plain profile.
+func (c *Element) SetCode(code string) {
+	c.code = code
+}
+
 func styleText(atomicNumber string, symbol string) string {
-	text := lipgloss.Place(width, height, 1, 1, symbol)
+	text := lipgloss.Place(cellSize.contentWidth(), height, 1, 1, symbol)
 	text = lipgloss.JoinVertical(0, lipgloss.Place(0, 0, 0, 0, atomicNumber), text)
 	return text
 }
@@ -122,20 +258,44 @@ func ElementInfoView(elmt Data) string {
 	body = lipgloss.Place(11, 10, 0, 0, body)
 
 	text := lipgloss.JoinVertical(0, heading, body)
-	style = style.BorderForeground(TypeColors[elmt.Type]).Width(22)
+	panelWidth := 22
+	if w := lipgloss.Width(body); w > panelWidth {
+		panelWidth = w
+	}
 
-	return style.Render(text)
+	return cellStyle().BorderForeground(TypeColor(elmt.Type)).Width(panelWidth).Render(text)
+}
+
+// Styles returns the selected and unselected style of a cell drawn in color.
+// In the plain profile the selected cell is drawn in reverse video instead.
+func Styles(color lipgloss.TerminalColor) (lipgloss.Style, lipgloss.Style) {
+	unSelectedStyle := cellStyle().BorderForeground(color)
+	return selected(unSelectedStyle, color), unSelectedStyle
+}
+
+// NoDataStyles returns the selected and unselected style of a cell which has
+// no value to be colored by.
+func NoDataStyles() (lipgloss.Style, lipgloss.Style) {
+	noData := theme.Current().NoData
+	unSelectedStyle := cellStyle().BorderStyle(border(noDataBorder, asciiNoDataBorder)).BorderForeground(noData)
+	return selected(unSelectedStyle, noData), unSelectedStyle
+}
+
+func selected(unSelectedStyle lipgloss.Style, color lipgloss.TerminalColor) lipgloss.Style {
+	if theme.IsPlain() {
+		return unSelectedStyle.Copy().Reverse(true)
+	}
+	return unSelectedStyle.Copy().Background(color).Foreground(theme.TextOn(color))
 }
 
 func CreateElement(data Data, isPaddingCell bool) grid.Cell {
-	unSelectedStyle := style.Copy().BorderForeground(TypeColors[data.Type])
-	selectedStyle := unSelectedStyle.Copy().Background(TypeColors[data.Type])
+	selectedStyle, unSelectedStyle := Styles(TypeColor(data.Type))
 
 	cell := &Element{
 		data:            data,
 		selectedStyle:   selectedStyle,
 		unSelectedStyle: unSelectedStyle,
-		searchString:    data.Element,
+		searchStrings:   searchStrings(data),
 		isSelected:      false,
 		isPaddingCell:   isPaddingCell,
 	}
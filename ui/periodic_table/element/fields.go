@@ -0,0 +1,154 @@
+package element
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind describes how the values of a Field should be interpreted.
+type Kind int
+
+const (
+	Text Kind = iota
+	Number
+	Flag
+)
+
+// Field describes a single property of Data. Name is the stable identifier
+// used in queries and machine readable output, Label is shown to people and
+// Unit, the unit of the data, is empty for dimensionless values. Get and
+// Float return values in Unit, Text and DisplayUnit in the units chosen in
+// settings.
+type Field struct {
+	Name  string
+	Label string
+	Unit  string
+	Kind  Kind
+	Get   func(d Data) string
+}
+
+// Float returns the field's value as a number. It returns false if the value
+// is missing or not numeric.
+func (f Field) Float(d Data) (float64, bool) {
+	value, err := strconv.ParseFloat(f.Get(d), 64)
+	return value, err == nil
+}
+
+// Value returns the field's value typed for machine readable output: a
+// float64 for numbers, a bool for flags and a string for text. It returns nil
+// if the value is missing.
+func (f Field) Value(d Data) interface{} {
+	switch f.Kind {
+	case Number:
+		if value, ok := f.Float(d); ok {
+			return value
+		}
+		return nil
+	case Flag:
+		return f.IsSet(d)
+	}
+	if value := f.Get(d); value != "" {
+		return value
+	}
+	return nil
+}
+
+// IsSet reports whether the field has a value. Flags are set when they hold
+// "yes".
+func (f Field) IsSet(d Data) bool {
+	value := f.Get(d)
+	if f.Kind == Flag {
+		return strings.EqualFold(value, "yes")
+	}
+	return value != ""
+}
+
+var Fields = []Field{
+	{"atomic_number", "Atomic number", "", Number, func(d Data) string { return d.AtomicNumber }},
+	{"name", "Name", "", Text, func(d Data) string { return d.Element }},
+	{"symbol", "Symbol", "", Text, func(d Data) string { return d.Symbol }},
+	{"atomic_mass", "Atomic mass", "u", Number, func(d Data) string { return d.AtomicMass }},
+	{"neutrons", "Neutrons", "", Number, func(d Data) string { return d.NumberOfNeutrons }},
+	{"protons", "Protons", "", Number, func(d Data) string { return d.NumberOfProtons }},
+	{"electrons", "Electrons", "", Number, func(d Data) string { return d.NumberOfElectrons }},
+	{"period", "Period", "", Number, func(d Data) string { return d.Period }},
+	{"group", "Group", "", Number, func(d Data) string { return d.Group }},
+	{"block", "Block", "", Text, func(d Data) string { return d.Block() }},
+	{"phase", "Phase", "", Text, func(d Data) string { return d.Phase }},
+	{"radioactive", "Radioactive", "", Flag, func(d Data) string { return d.Radioactive }},
+	{"natural", "Natural", "", Flag, func(d Data) string { return d.Natural }},
+	{"metal", "Metal", "", Flag, func(d Data) string { return d.Metal }},
+	{"nonmetal", "Nonmetal", "", Flag, func(d Data) string { return d.Nonmetal }},
+	{"metalloid", "Metalloid", "", Flag, func(d Data) string { return d.Metalloid }},
+	{"type", "Type", "", Text, func(d Data) string { return d.Type }},
+	{"atomic_radius", "Atomic radius", "Å", Number, func(d Data) string { return d.AtomicRadius }},
+	{"electronegativity", "Electronegativity", "Pauling", Number, func(d Data) string { return d.Electronegativity }},
+	{"first_ionization", "First ionization", "eV", Number, func(d Data) string { return d.FirstIonization }},
+	{"density", "Density", "g/cm³", Number, func(d Data) string { return d.Density }},
+	{"melting_point", "Melting point", "K", Number, func(d Data) string { return d.MeltingPoint }},
+	{"boiling_point", "Boiling point", "K", Number, func(d Data) string { return d.BoilingPoint }},
+	{"isotopes", "Isotopes", "", Number, func(d Data) string { return d.NumberOfIsotopes }},
+	{"discoverer", "Discoverer", "", Text, func(d Data) string { return d.Discoverer }},
+	{"year", "Year", "", Number, func(d Data) string { return d.Year }},
+	{"specific_heat", "Specific heat", "J/(g·K)", Number, func(d Data) string { return d.SpecificHeat }},
+	{"shells", "Shells", "", Number, func(d Data) string { return d.NumberOfShells }},
+	{"valence", "Valence electrons", "", Number, func(d Data) string { return d.NumberOfValence }},
+}
+
+// fieldAliases lets common alternative spellings refer to a field.
+var fieldAliases = map[string]string{
+	"number":     "atomic_number",
+	"element":    "name",
+	"mass":       "atomic_mass",
+	"radius":     "atomic_radius",
+	"ionization": "first_ionization",
+	"category":   "type",
+}
+
+// FieldByName looks up a field by its name or one of its aliases, ignoring
+// case.
+func FieldByName(name string) (Field, bool) {
+	name = strings.ToLower(name)
+	if alias, ok := fieldAliases[name]; ok {
+		name = alias
+	}
+
+	for _, f := range Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Sort sorts data by field, comparing numeric fields by value. Elements
+// without a value always come last, whichever the direction.
+func Sort(data []Data, field Field, descending bool) {
+	sort.SliceStable(data, func(i, j int) bool {
+		return less(field, data[i], data[j], descending)
+	})
+}
+
+func less(field Field, a, b Data, descending bool) bool {
+	if field.Kind == Number {
+		x, xOk := field.Float(a)
+		y, yOk := field.Float(b)
+		if !xOk || !yOk {
+			return xOk && !yOk
+		}
+		if descending {
+			return x > y
+		}
+		return x < y
+	}
+
+	x, y := strings.ToLower(field.Get(a)), strings.ToLower(field.Get(b))
+	if x == "" || y == "" {
+		return x != "" && y == ""
+	}
+	if descending {
+		return x > y
+	}
+	return x < y
+}
@@ -0,0 +1,42 @@
+package element
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	data := []Data{
+		{Symbol: "He", Density: "1.79E-04", Phase: "gas"},
+		{Symbol: "Og"},
+		{Symbol: "Fe", Density: "7.87", Phase: "solid"},
+		{Symbol: "Na", Density: "0.971", Phase: "solid"},
+		{Symbol: "Hg", Density: "13.5", Phase: "liquid"},
+	}
+
+	tests := []struct {
+		field      string
+		descending bool
+		want       string
+	}{
+		{field: "density", want: "He,Na,Fe,Hg,Og"},
+		{field: "density", descending: true, want: "Hg,Fe,Na,He,Og"},
+		{field: "phase", want: "He,Hg,Fe,Na,Og"},
+		{field: "phase", descending: true, want: "Fe,Na,Hg,He,Og"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			field, _ := FieldByName(tt.field)
+			rows := append([]Data(nil), data...)
+			Sort(rows, field, tt.descending)
+
+			var symbols []string
+			for _, d := range rows {
+				symbols = append(symbols, d.Symbol)
+			}
+			if got := strings.Join(symbols, ","); got != tt.want {
+				t.Errorf("Sort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package element
+
+import (
+	"math"
+	"periodic-table/ui/periodic_table/theme"
+	"strconv"
+	"strings"
+)
+
+// TemperatureUnit is a unit temperatures are shown in. The data is in
+// kelvin.
+type TemperatureUnit string
+
+const (
+	Kelvin     TemperatureUnit = "K"
+	Celsius    TemperatureUnit = "°C"
+	Fahrenheit TemperatureUnit = "°F"
+)
+
+// TemperatureUnits maps the names used in settings to units.
+var TemperatureUnits = map[string]TemperatureUnit{
+	"kelvin":     Kelvin,
+	"celsius":    Celsius,
+	"fahrenheit": Fahrenheit,
+}
+
+var temperatureUnit = Kelvin
+
+// temperatureFields are the fields holding temperatures.
+var temperatureFields = []string{"melting_point", "boiling_point"}
+
+// SetTemperatureUnit changes the unit temperatures are shown in.
+func SetTemperatureUnit(unit TemperatureUnit) {
+	temperatureUnit = unit
+}
+
+func (f Field) isTemperature() bool {
+	for _, name := range temperatureFields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Text returns the field's value as it is shown, with temperatures in the
+// unit chosen in settings.
+func (f Field) Text(d Data) string {
+	value := f.Get(d)
+	if !f.isTemperature() || temperatureUnit == Kelvin {
+		return value
+	}
+	kelvin, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	return strconv.FormatFloat(math.Round(f.Convert(kelvin)*100)/100, 'f', -1, 64)
+}
+
+// asciiUnits spell the units outside ASCII for the plain profile.
+var asciiUnits = map[string]string{
+	"°C":      "degC",
+	"°F":      "degF",
+	"Å":       "A",
+	"g/cm³":   "g/cm3",
+	"J/(g·K)": "J/(g*K)",
+}
+
+// DisplayUnit returns the unit Text shows the field's values in, spelled in
+// ASCII in the plain profile.
+func (f Field) DisplayUnit() string {
+	unit := f.Unit
+	if f.isTemperature() {
+		unit = string(temperatureUnit)
+	}
+	if ascii, ok := asciiUnits[unit]; ok && theme.IsPlain() {
+		return ascii
+	}
+	return unit
+}
+
+// Convert converts a value of the field from Unit to DisplayUnit.
+func (f Field) Convert(value float64) float64 {
+	if !f.isTemperature() {
+		return value
+	}
+	switch temperatureUnit {
+	case Celsius:
+		return value - 273.15
+	case Fahrenheit:
+		return value*9/5 - 459.67
+	}
+	return value
+}
+
+var (
+	cellFields = [2]string{"atomic_number", "symbol"}
+	infoFields = []string{"type", "atomic_number", "atomic_mass", "electrons", "protons", "neutrons",
+		"group", "density", "atomic_radius", "melting_point", "specific_heat"}
+)
+
+// SetCellFields changes the fields shown in the top left and bottom right of
+// each cell of the table.
+func SetCellFields(top, bottom string) {
+	cellFields = [2]string{top, bottom}
+}
+
+// SetInfoFields changes the fields listed in the info panel beside the table.
+func SetInfoFields(names []string) {
+	infoFields = names
+}
+
+// CellFields returns the fields shown in each cell of the table.
+func CellFields() (top, bottom string) {
+	return cellFields[0], cellFields[1]
+}
+
+// InfoFields returns the fields listed in the info panel.
+func InfoFields() []string {
+	return infoFields
+}
+
+// fieldValue returns the value of the named field as it is shown, or an
+// empty string if there is no such field.
+func fieldValue(name string, d Data) string {
+	if f, ok := FieldByName(name); ok {
+		return f.Text(d)
+	}
+	return ""
+}
+
+// truncate shortens s to at most n runes.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:n]))
+}
+
+// CellSize is how large the cells of the table are drawn.
+type CellSize int
+
+const (
+	// Normal cells have a border around two lines of six columns.
+	Normal CellSize = iota
+	// Compact cells are two columns narrower than normal ones.
+	Compact
+	// Tiny cells have no border and show only the bottom field, on the
+	// color of the element, in a single line.
+	Tiny
+)
+
+// CellSizes are the cell sizes from the largest to the smallest.
+var CellSizes = []CellSize{Normal, Compact, Tiny}
+
+var cellSize = Normal
+
+// SetCellSize changes the size cells are drawn in. Cells created before
+// take the new size once they are styled again.
+func SetCellSize(size CellSize) {
+	cellSize = size
+}
+
+// Width returns the columns a cell of the size takes up.
+func (s CellSize) Width() int {
+	if s == Tiny {
+		return s.contentWidth()
+	}
+	return s.contentWidth() + 2
+}
+
+// contentWidth returns the columns inside the border of a cell of the size.
+func (s CellSize) contentWidth() int {
+	switch s {
+	case Compact, Tiny:
+		return width - 2
+	}
+	return width
+}
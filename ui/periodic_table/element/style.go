@@ -1,17 +1,56 @@
 package element
 
 import (
+	"periodic-table/ui/periodic_table/theme"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
 const (
 	width  = 6
 	height = 1
+	marker = "•"
+	// asciiMarker marks pinned elements in the plain profile.
+	asciiMarker = "*"
 )
 
 var (
-	style      = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).Width(width).Height(height)
-	empty      = lipgloss.NewStyle().Width(width + 2).Height(height + 1)
+	style           = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).Width(width).Height(height)
+	empty           = lipgloss.NewStyle().Width(width + 2).Height(height + 1)
+	highlightBorder = lipgloss.ThickBorder()
+	noDataBorder    = lipgloss.Border{
+		Top:         "╌",
+		Bottom:      "╌",
+		Left:        "╎",
+		Right:       "╎",
+		TopLeft:     "┌",
+		TopRight:    "┐",
+		BottomLeft:  "└",
+		BottomRight: "┘",
+	}
+	// The ASCII borders stand in for the borders above in the plain profile.
+	asciiHighlightBorder = lipgloss.Border{
+		Top:         "=",
+		Bottom:      "=",
+		Left:        "#",
+		Right:       "#",
+		TopLeft:     "#",
+		TopRight:    "#",
+		BottomLeft:  "#",
+		BottomRight: "#",
+	}
+	asciiNoDataBorder = lipgloss.Border{
+		Top:         ".",
+		Bottom:      ".",
+		Left:        ":",
+		Right:       ":",
+		TopLeft:     ".",
+		TopRight:    ".",
+		BottomLeft:  ":",
+		BottomRight: ":",
+	}
+	// TypeColors are the base colors of the element types, which themes
+	// adapt. Use TypeColor for the color in the current theme.
 	TypeColors = map[string]lipgloss.Color{
 		"Nonmetal":             lipgloss.Color("#cf53a4"),
 		"Noble Gas":            lipgloss.Color("#697a90"),
@@ -24,7 +63,6 @@ var (
 		"Lanthanide":           lipgloss.Color("#4b93c6"),
 		"Metal":                lipgloss.Color("#136F63"),
 		"Halogen":              lipgloss.Color("#052cbf"),
-		"artificial":           lipgloss.Color("#f9c857"),
 	}
 )
 
@@ -42,3 +80,50 @@ func getView(atomicNumber string, symbol string, isSelected bool, elementType st
 
 	return style.Render(text)
 }
+
+// TypeColor returns the color of an element type in the current theme.
+func TypeColor(elementType string) lipgloss.TerminalColor {
+	return theme.Current().Category("type", elementType, TypeColors[elementType])
+}
+
+// cellStyle is the style of a cell before it is colored, with an ASCII
+// border in the plain profile.
+func cellStyle() lipgloss.Style {
+	return style.Copy().Width(cellSize.contentWidth()).BorderStyle(theme.Border(lipgloss.NormalBorder()))
+}
+
+// emptyStyle draws padding cells, as wide as the other cells.
+func emptyStyle() lipgloss.Style {
+	if cellSize == Tiny {
+		return empty.Copy().Width(cellSize.Width()).Height(height)
+	}
+	return empty.Copy().Width(cellSize.Width())
+}
+
+// tinyStyle is the style of a tiny cell of the given color, which has no
+// border to color so it is drawn on the color instead.
+func tinyStyle(color lipgloss.TerminalColor) lipgloss.Style {
+	style := lipgloss.NewStyle().Width(cellSize.Width())
+	if theme.IsPlain() {
+		return style
+	}
+	return style.Background(color).Foreground(theme.TextOn(color))
+}
+
+// border returns b, or ascii in the plain profile.
+func border(b, ascii lipgloss.Border) lipgloss.Border {
+	if theme.IsPlain() {
+		return ascii
+	}
+	return b
+}
+
+// dimmedStyle draws elements which don't match a filter. Without colors to
+// fade them they lose their border instead.
+func dimmedStyle() lipgloss.Style {
+	if theme.IsPlain() {
+		return cellStyle().BorderStyle(lipgloss.HiddenBorder())
+	}
+	t := theme.Current()
+	return cellStyle().BorderForeground(t.Dimmed).Foreground(t.DimmedText)
+}
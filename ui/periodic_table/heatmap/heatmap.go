@@ -0,0 +1,220 @@
+// Package heatmap colors elements on a gradient by one of their numeric
+// properties.
+package heatmap
+
+import (
+	"fmt"
+	"math"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/theme"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type Scale int
+
+const (
+	Linear Scale = iota
+	Log
+)
+
+func (s Scale) String() string {
+	if s == Log {
+		return "log"
+	}
+	return "linear"
+}
+
+// Properties are the names of the fields a heatmap can be drawn for, in the
+// order they are cycled through.
+var Properties = []string{
+	"electronegativity",
+	"density",
+	"atomic_radius",
+	"first_ionization",
+	"melting_point",
+	"boiling_point",
+	"atomic_mass",
+	"specific_heat",
+}
+
+// gradient is sampled from the viridis color map, which stays readable for
+// colorblind users and when printed in grey.
+var gradient = []rgb{
+	{0x44, 0x01, 0x54},
+	{0x3b, 0x52, 0x8b},
+	{0x21, 0x91, 0x8c},
+	{0x5e, 0xc9, 0x62},
+	{0xfd, 0xe7, 0x25},
+}
+
+// ramp stands in for the gradient without color, from sparse characters for
+// the lowest values to dense ones for the highest.
+const ramp = ".:-=+*#%@"
+
+type Heatmap struct {
+	Field    element.Field
+	Scale    Scale
+	min, max float64
+}
+
+// New creates a heatmap for field whose range spans the values found in data.
+func New(field element.Field, scale Scale, data []element.Data) Heatmap {
+	h := Heatmap{Field: field, Scale: scale, min: math.Inf(1), max: math.Inf(-1)}
+	for _, d := range data {
+		if value, ok := h.value(d); ok {
+			h.min = math.Min(h.min, value)
+			h.max = math.Max(h.max, value)
+		}
+	}
+	return h
+}
+
+func (h Heatmap) Name() string {
+	return h.Field.Label + " heatmap"
+}
+
+// value returns the value of d on the heatmap's scale.
+func (h Heatmap) value(d element.Data) (float64, bool) {
+	value, ok := h.Field.Float(d)
+	if !ok {
+		return 0, false
+	}
+
+	if h.Scale == Log {
+		if value <= 0 {
+			return 0, false
+		}
+		return math.Log10(value), true
+	}
+	return value, true
+}
+
+// Color returns the color of d on the gradient. It returns false if d has no
+// value for the heatmap's field.
+func (h Heatmap) Color(d element.Data) (lipgloss.TerminalColor, bool) {
+	value, ok := h.value(d)
+	if !ok {
+		return nil, false
+	}
+
+	return ColorAt(h.position(value)), true
+}
+
+// Code returns the character of the ramp for d, as the heatmap is drawn in
+// the plain profile.
+func (h Heatmap) Code(d element.Data) string {
+	value, ok := h.value(d)
+	if !ok {
+		return ""
+	}
+	return rampAt(h.position(value))
+}
+
+// position returns where value lies between the minimum and maximum, from 0
+// to 1.
+func (h Heatmap) position(value float64) float64 {
+	if h.max > h.min {
+		return (value - h.min) / (h.max - h.min)
+	}
+	return 0
+}
+
+// Min returns the smallest value in the data the heatmap was created for.
+func (h Heatmap) Min() float64 {
+	return h.unscale(h.min)
+}
+
+// Max returns the largest value in the data the heatmap was created for.
+func (h Heatmap) Max() float64 {
+	return h.unscale(h.max)
+}
+
+func (h Heatmap) unscale(value float64) float64 {
+	if h.Scale == Log {
+		return math.Pow(10, value)
+	}
+	return value
+}
+
+// Range returns the minimum and maximum formatted for the legend, in the unit
+// the field is shown in.
+func (h Heatmap) Range() (low, high string) {
+	return FormatValue(h.Field.Convert(h.Min())), FormatValue(h.Field.Convert(h.Max()))
+}
+
+// Title names the field and scale of the heatmap, as the legend is headed.
+func (h Heatmap) Title() string {
+	title := h.Field.Label
+	if unit := h.Field.DisplayUnit(); unit != "" {
+		title += " (" + unit + ")"
+	}
+	return title + ", " + h.Scale.String() + " scale"
+}
+
+// Legend renders a bar of the given width showing the gradient between the
+// minimum and maximum value.
+func (h Heatmap) Legend(width int) string {
+	title := h.Title()
+	low, high := h.Range()
+	barWidth := width - lipgloss.Width(low) - lipgloss.Width(high) - 2
+	if barWidth < 1 {
+		barWidth = 1
+	}
+
+	var bar strings.Builder
+	for i := 0; i < barWidth; i++ {
+		position := 0.0
+		if barWidth > 1 {
+			position = float64(i) / float64(barWidth-1)
+		}
+		if theme.IsPlain() {
+			bar.WriteString(rampAt(position))
+		} else {
+			bar.WriteString(lipgloss.NewStyle().Foreground(ColorAt(position)).Render("█"))
+		}
+	}
+
+	noData := noDataStyle().Render("╌╌ no data")
+	if theme.IsPlain() {
+		noData = ".. no data"
+	}
+	scale := low + " " + bar.String() + " " + high
+	return lipgloss.JoinVertical(0, title+"   "+noData, scale)
+}
+
+// FormatValue formats a value for the legend.
+func FormatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', 4, 64)
+}
+
+func rampAt(position float64) string {
+	i := int(math.Max(0, position) * float64(len(ramp)))
+	if i >= len(ramp) {
+		i = len(ramp) - 1
+	}
+	return ramp[i : i+1]
+}
+
+type rgb struct{ r, g, b uint8 }
+
+// ColorAt returns the color of the gradient at position, from 0 for the
+// lowest value to 1 for the highest.
+func ColorAt(position float64) lipgloss.Color {
+	position = math.Max(0, math.Min(1, position))
+
+	scaled := position * float64(len(gradient)-1)
+	i := int(scaled)
+	if i >= len(gradient)-1 {
+		i = len(gradient) - 2
+	}
+	t := scaled - float64(i)
+
+	from, to := gradient[i], gradient[i+1]
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*t))
+	}
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", mix(from.r, to.r), mix(from.g, to.g), mix(from.b, to.b)))
+}
@@ -0,0 +1,132 @@
+package heatmap
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newHeatmap(t *testing.T, scale Scale, densities ...string) Heatmap {
+	t.Helper()
+	field, ok := element.FieldByName("density")
+	if !ok {
+		t.Fatal("no density field")
+	}
+	var data []element.Data
+	for _, density := range densities {
+		data = append(data, element.Data{Density: density})
+	}
+	return New(field, scale, data)
+}
+
+func TestHeatmap(t *testing.T) {
+	tests := []struct {
+		name     string
+		scale    Scale
+		data     []string
+		min, max float64
+		density  string
+		code     string
+		color    lipgloss.TerminalColor
+	}{
+		{"linear lowest", Linear, []string{"0", "5", "10"}, 0, 10, "0", ".", lipgloss.Color("#440154")},
+		{"linear middle", Linear, []string{"0", "5", "10"}, 0, 10, "5", "+", lipgloss.Color("#21918c")},
+		{"linear highest", Linear, []string{"0", "5", "10"}, 0, 10, "10", "@", lipgloss.Color("#fde725")},
+		{"linear negative", Linear, []string{"-10", "10"}, -10, 10, "0", "+", lipgloss.Color("#21918c")},
+		{"linear missing", Linear, []string{"0", "", "10"}, 0, 10, "", "", nil},
+		{"log middle", Log, []string{"1", "10", "100"}, 1, 100, "10", "+", lipgloss.Color("#21918c")},
+		{"log highest", Log, []string{"1", "10", "100"}, 1, 100, "100", "@", lipgloss.Color("#fde725")},
+		{"log zero", Log, []string{"0", "1", "100"}, 1, 100, "0", "", nil},
+		{"log negative", Log, []string{"-5", "1", "100"}, 1, 100, "-5", "", nil},
+		{"single value", Linear, []string{"3"}, 3, 3, "3", ".", lipgloss.Color("#440154")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHeatmap(t, tt.scale, tt.data...)
+			if h.Min() != tt.min || h.Max() != tt.max {
+				t.Errorf("Min(), Max() = %v, %v, want %v, %v", h.Min(), h.Max(), tt.min, tt.max)
+			}
+
+			d := element.Data{Density: tt.density}
+			if got := h.Code(d); got != tt.code {
+				t.Errorf("Code() = %q, want %q", got, tt.code)
+			}
+			color, ok := h.Color(d)
+			if ok != (tt.color != nil) || color != tt.color {
+				t.Errorf("Color() = %v, %v, want %v", color, ok, tt.color)
+			}
+		})
+	}
+}
+
+func TestColorAt(t *testing.T) {
+	tests := []struct {
+		position float64
+		want     lipgloss.Color
+	}{
+		{-1, "#440154"},
+		{0, "#440154"},
+		{0.125, "#402a70"},
+		{0.25, "#3b528b"},
+		{1, "#fde725"},
+		{2, "#fde725"},
+	}
+	for _, tt := range tests {
+		if got := ColorAt(tt.position); got != tt.want {
+			t.Errorf("ColorAt(%v) = %v, want %v", tt.position, got, tt.want)
+		}
+	}
+}
+
+func TestRampAt(t *testing.T) {
+	tests := []struct {
+		position float64
+		want     string
+	}{
+		{-1, "."},
+		{0, "."},
+		{0.2, ":"},
+		{0.5, "+"},
+		{0.99, "@"},
+		{1, "@"},
+		{2, "@"},
+	}
+	for _, tt := range tests {
+		if got := rampAt(tt.position); got != tt.want {
+			t.Errorf("rampAt(%v) = %q, want %q", tt.position, got, tt.want)
+		}
+	}
+}
+
+func TestLegend(t *testing.T) {
+	h := newHeatmap(t, Log, "0", "0.0899", "22.59")
+	legend := h.Legend(40)
+	for _, want := range []string{"Density (g/cm³), log scale", "no data", "0.0899 ", " 22.59"} {
+		if !strings.Contains(legend, want) {
+			t.Errorf("Legend() = %q, want it to contain %q", legend, want)
+		}
+	}
+	if got := lipgloss.Width(strings.Split(legend, "\n")[1]); got != 40 {
+		t.Errorf("Legend() bar is %d wide, want 40", got)
+	}
+}
+
+func TestHeatmap_TemperatureUnit(t *testing.T) {
+	element.SetTemperatureUnit(element.Celsius)
+	defer element.SetTemperatureUnit(element.Kelvin)
+
+	field, _ := element.FieldByName("melting_point")
+	hydrogen, iron := element.Data{MeltingPoint: "14.175"}, element.Data{MeltingPoint: "1811"}
+	h := New(field, Log, []element.Data{hydrogen, iron})
+	if _, ok := h.Color(hydrogen); !ok {
+		t.Error("Color() has no data for a melting point below 0 °C")
+	}
+	if got := h.Title(); got != "Melting point (°C), log scale" {
+		t.Errorf("Title() = %q", got)
+	}
+	if low, high := h.Range(); low != "-259" || high != "1538" {
+		t.Errorf("Range() = %q, %q, want -259, 1538", low, high)
+	}
+}
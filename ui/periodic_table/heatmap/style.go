@@ -0,0 +1,11 @@
+package heatmap
+
+import (
+	"periodic-table/ui/periodic_table/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func noDataStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Current().NoData)
+}
@@ -0,0 +1,59 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// CalculatorKeyMap defines the keybindings of the calculator. Printable keys
+// go to the input, so every binding uses a special key.
+type CalculatorKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	NextTab key.Binding
+	PrevTab key.Binding
+	Back    key.Binding
+	Quit    key.Binding
+}
+
+func (k CalculatorKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.PrevTab, k.NextTab, k.Up, k.Down, k.Back, k.Quit}
+}
+
+func (k CalculatorKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.PrevTab, k.NextTab},
+		{k.Up, k.Down},
+		{k.Back, k.Quit},
+	}
+}
+
+var calculatorKeys = CalculatorKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up"),
+		key.WithHelp("↑", "scroll up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down"),
+		key.WithHelp("↓", "scroll down"),
+	),
+	NextTab: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "next tool"),
+	),
+	PrevTab: key.NewBinding(
+		key.WithKeys("shift+tab"),
+		key.WithHelp("shift+tab", "previous tool"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back to table"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "quit"),
+	),
+}
+
+func CreateCalculatorKeys() CalculatorKeyMap {
+	return calculatorKeys
+}
@@ -0,0 +1,63 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// CompareKeyMap defines the keybindings of the compare view.
+type CompareKeyMap struct {
+	Up            key.Binding
+	Down          key.Binding
+	PrevReference key.Binding
+	NextReference key.Binding
+	Back          key.Binding
+	Help          key.Binding
+	Quit          key.Binding
+}
+
+func (k CompareKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.PrevReference, k.NextReference, k.Back, k.Help, k.Quit}
+}
+
+func (k CompareKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.PrevReference, k.NextReference},
+		{k.Back, k.Help, k.Quit},
+	}
+}
+
+var compareKeys = CompareKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "scroll up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "scroll down"),
+	),
+	PrevReference: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "previous reference"),
+	),
+	NextReference: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "next reference"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("P", "esc"),
+		key.WithHelp("P/esc", "back to table"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "toggle help"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q/ctrl+c", "quit"),
+	),
+}
+
+func CreateCompareKeys() CompareKeyMap {
+	return compareKeys
+}
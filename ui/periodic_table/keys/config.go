@@ -0,0 +1,269 @@
+package keys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// Remaps replaces the keys of bindings. It is keyed by the name of a key map
+// ("table", "list", "compare", "detail" or "calculator") and then by the
+// name of an action, for example {"table": {"pin": ["space"]}}. An empty
+// list of keys disables the action.
+type Remaps map[string]map[string][]string
+
+// keyMap names the bindings of a key map for remapping. Each context lists
+// the actions which are handled together and so must not share a key.
+type keyMap struct {
+	bindings map[string]*key.Binding
+	contexts map[string][]string
+}
+
+// countKeys start the count of a table command such as "26G", or set the
+// count of the last part of a compound being built, so they can't be bound
+// to table actions.
+var countKeys = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+
+func keyMaps(t *KeyMap, l *ListKeyMap, c *CompareKeyMap, d *DetailKeyMap, k *CalculatorKeyMap) map[string]keyMap {
+	return map[string]keyMap{
+		"table": {
+			bindings: map[string]*key.Binding{
+				"up": &t.Up, "down": &t.Down, "left": &t.Left, "right": &t.Right,
+				"help": &t.Help, "quit": &t.Quit, "search": &t.Search, "filter": &t.Filter,
+				"list": &t.List, "details": &t.Details, "confirm": &t.Confirm, "cancel": &t.Cancel,
+				"pin": &t.Pin, "clear-pins": &t.ClearPins, "compare": &t.Compare,
+				"calculator":      &t.Calculator,
+				"add-to-compound": &t.AddToCompound, "open-group": &t.OpenGroup,
+				"close-group": &t.CloseGroup, "delete-from-compound": &t.DeleteFromCompound,
+				"next-scheme": &t.NextScheme, "prev-scheme": &t.PrevScheme,
+				"heatmap": &t.Heatmap, "heatmap-scale": &t.HeatmapScale,
+				"legend": &t.Legend, "choose-category": &t.ChooseCategory,
+				"next-type": &t.NextType, "prev-type": &t.PrevType,
+				"next-group": &t.NextGroup, "prev-group": &t.PrevGroup,
+				"next-period": &t.NextPeriod, "prev-period": &t.PrevPeriod,
+				"next-block": &t.NextBlock, "prev-block": &t.PrevBlock,
+				"go-to": &t.GoTo, "next-match": &t.NextMatch, "prev-match": &t.PrevMatch,
+				"next-result": &t.NextResult, "prev-result": &t.PrevResult,
+			},
+			contexts: map[string][]string{
+				"grid": {
+					"up", "down", "left", "right", "help", "quit", "search", "filter",
+					"list", "details", "cancel", "pin", "clear-pins", "compare", "calculator",
+					"next-scheme", "prev-scheme", "heatmap", "heatmap-scale", "legend",
+					"next-type", "prev-type", "next-group", "prev-group",
+					"next-period", "prev-period", "next-block", "prev-block",
+					"go-to", "next-match", "prev-match",
+					"add-to-compound", "open-group", "close-group", "delete-from-compound",
+				},
+				"legend": {"up", "down", "left", "right", "quit", "legend", "cancel", "choose-category"},
+				"search": {"quit", "confirm", "cancel", "next-result", "prev-result"},
+				"help":   {"quit", "help", "cancel"},
+			},
+		},
+		"list": {
+			bindings: map[string]*key.Binding{
+				"up": &l.Up, "down": &l.Down, "page-up": &l.PageUp, "page-down": &l.PageDown,
+				"top": &l.Top, "bottom": &l.Bottom,
+				"prev-column": &l.PrevColumn, "next-column": &l.NextColumn,
+				"sort": &l.Sort, "filter": &l.Filter, "columns": &l.Columns,
+				"toggle-field": &l.ToggleField, "select": &l.Select,
+				"confirm": &l.Confirm, "cancel": &l.Cancel,
+				"back": &l.Back, "help": &l.Help, "quit": &l.Quit,
+			},
+			contexts: map[string][]string{
+				"browse": {
+					"up", "down", "page-up", "page-down", "top", "bottom",
+					"prev-column", "next-column", "sort", "filter", "columns",
+					"select", "back", "help", "quit",
+				},
+				"filter":  {"confirm", "cancel", "quit"},
+				"columns": {"up", "down", "toggle-field", "columns", "back", "select"},
+			},
+		},
+		"compare": {
+			bindings: map[string]*key.Binding{
+				"up": &c.Up, "down": &c.Down,
+				"prev-reference": &c.PrevReference, "next-reference": &c.NextReference,
+				"back": &c.Back, "help": &c.Help, "quit": &c.Quit,
+			},
+		},
+		"detail": {
+			bindings: map[string]*key.Binding{
+				"up": &d.Up, "down": &d.Down, "next-tab": &d.NextTab, "prev-tab": &d.PrevTab,
+				"raise-charge": &d.RaiseCharge, "lower-charge": &d.LowerCharge,
+				"back": &d.Back, "help": &d.Help, "quit": &d.Quit,
+			},
+		},
+		"calculator": {
+			bindings: map[string]*key.Binding{
+				"up": &k.Up, "down": &k.Down, "next-tab": &k.NextTab, "prev-tab": &k.PrevTab,
+				"back": &k.Back, "quit": &k.Quit,
+			},
+		},
+	}
+}
+
+// defaults are the key maps before any remaps are applied.
+var defaults = struct {
+	table      KeyMap
+	list       ListKeyMap
+	compare    CompareKeyMap
+	detail     DetailKeyMap
+	calculator CalculatorKeyMap
+}{keys, listKeys, compareKeys, detailKeys, calculatorKeys}
+
+// ConflictError reports two actions which are active at the same time and
+// share a key.
+type ConflictError struct {
+	Map     string
+	Key     string
+	Actions [2]string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: key %q is bound to both %s and %s", e.Map, e.Key, e.Actions[0], e.Actions[1])
+}
+
+// Apply replaces the default keys of the bindings named in remaps. The key
+// maps are left unchanged if a name is unknown or if two actions which are
+// active at the same time end up sharing a key.
+func Apply(remaps Remaps) error {
+	t, l, c, d, k := defaults.table, defaults.list, defaults.compare, defaults.detail, defaults.calculator
+	if err := remap(&t, &l, &c, &d, &k, remaps); err != nil {
+		return err
+	}
+	keys, listKeys, compareKeys, detailKeys, calculatorKeys = t, l, c, d, k
+	return nil
+}
+
+// Check reports the error Apply would return for remaps without changing
+// any keys.
+func Check(remaps Remaps) error {
+	t, l, c, d, k := defaults.table, defaults.list, defaults.compare, defaults.detail, defaults.calculator
+	return remap(&t, &l, &c, &d, &k, remaps)
+}
+
+func remap(t *KeyMap, l *ListKeyMap, c *CompareKeyMap, d *DetailKeyMap, k *CalculatorKeyMap, remaps Remaps) error {
+	maps := keyMaps(t, l, c, d, k)
+
+	for _, mapName := range sortedKeys(remaps) {
+		km, ok := maps[mapName]
+		if !ok {
+			return fmt.Errorf("unknown key map %q, expected one of %s", mapName, strings.Join(sortedKeys(maps), ", "))
+		}
+		for _, action := range sortedKeys(remaps[mapName]) {
+			binding, ok := km.bindings[action]
+			if !ok {
+				return fmt.Errorf("%s: unknown action %q", mapName, action)
+			}
+			rebind(binding, remaps[mapName][action])
+		}
+	}
+
+	for _, mapName := range sortedKeys(maps) {
+		if err := maps[mapName].checkConflicts(mapName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Defaults returns the default keys of every action, in the form read by
+// Apply.
+func Defaults() Remaps {
+	t, l, c, d, k := defaults.table, defaults.list, defaults.compare, defaults.detail, defaults.calculator
+	remaps := Remaps{}
+	for mapName, km := range keyMaps(&t, &l, &c, &d, &k) {
+		remaps[mapName] = map[string][]string{}
+		for action, binding := range km.bindings {
+			var names []string
+			for _, k := range binding.Keys() {
+				if k == " " {
+					k = "space"
+				}
+				names = append(names, k)
+			}
+			remaps[mapName][action] = names
+		}
+	}
+	return remaps
+}
+
+// Maps returns the names of the key maps which can be remapped.
+func Maps() []string {
+	var t KeyMap
+	var l ListKeyMap
+	var c CompareKeyMap
+	var d DetailKeyMap
+	var k CalculatorKeyMap
+	return sortedKeys(keyMaps(&t, &l, &c, &d, &k))
+}
+
+// Actions returns the names of the actions of a key map.
+func Actions(mapName string) ([]string, bool) {
+	var t KeyMap
+	var l ListKeyMap
+	var c CompareKeyMap
+	var d DetailKeyMap
+	var k CalculatorKeyMap
+	km, ok := keyMaps(&t, &l, &c, &d, &k)[mapName]
+	return sortedKeys(km.bindings), ok
+}
+
+func rebind(binding *key.Binding, keys []string) {
+	if len(keys) == 0 {
+		binding.SetEnabled(false)
+		return
+	}
+	// Bubble Tea reports the space bar as " ", which is awkward to write.
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		if k == "space" {
+			k = " "
+		}
+		names[i] = k
+	}
+	binding.SetKeys(names...)
+	binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+	binding.SetEnabled(true)
+}
+
+func (km keyMap) checkConflicts(mapName string) error {
+	contexts := km.contexts
+	if contexts == nil {
+		contexts = map[string][]string{"": sortedKeys(km.bindings)}
+	}
+
+	for _, context := range sortedKeys(contexts) {
+		owners := map[string]string{}
+		if mapName == "table" && context == "grid" {
+			for _, k := range countKeys {
+				owners[k] = "the count prefix"
+			}
+		}
+		for _, action := range contexts[context] {
+			binding := km.bindings[action]
+			if !binding.Enabled() {
+				continue
+			}
+			for _, k := range binding.Keys() {
+				if owner, ok := owners[k]; ok && owner != action {
+					return &ConflictError{Map: mapName, Key: k, Actions: [2]string{owner, action}}
+				}
+				owners[k] = action
+			}
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
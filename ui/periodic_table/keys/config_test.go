@@ -0,0 +1,61 @@
+package keys
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	defaults := keys
+	defer func() { keys = defaults }()
+
+	tests := []struct {
+		name   string
+		remaps Remaps
+		err    string
+	}{
+		{"defaults", Remaps{}, ""},
+		{"remap", Remaps{"table": {"pin": {"space"}}}, ""},
+		{"swap", Remaps{"table": {"pin": {"x"}, "clear-pins": {"p"}}}, ""},
+		{"disable", Remaps{"table": {"clear-pins": {}, "pin": {"x"}}}, ""},
+		{"conflict", Remaps{"table": {"pin": {"x"}}}, `key "x" is bound to both`},
+		{"count prefix", Remaps{"table": {"pin": {"1"}}}, "count prefix"},
+		{"other context", Remaps{"table": {"choose-category": {"p"}}}, ""},
+		{"unknown map", Remaps{"grid": {"pin": {"p"}}}, "unknown key map"},
+		{"unknown action", Remaps{"list": {"pin": {"p"}}}, "unknown action"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys = defaults
+			err := Apply(tt.remaps)
+			if tt.err == "" && err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Fatalf("Apply() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestApply_UpdatesBindings(t *testing.T) {
+	defaults := keys
+	defer func() { keys = defaults }()
+
+	if err := Apply(Remaps{"table": {"pin": {"space", "="}}}); err != nil {
+		t.Fatal(err)
+	}
+	pin := CreateKeys().Pin
+	if got := strings.Join(pin.Keys(), ","); got != " ,=" {
+		t.Errorf("Pin keys = %q", got)
+	}
+	if got := pin.Help().Key; got != "space/=" {
+		t.Errorf("Pin help = %q", got)
+	}
+	if err := Apply(Remaps{"table": {"pin": {"x"}}}); err == nil {
+		t.Fatal("Apply() accepted a conflict")
+	}
+	if got := strings.Join(CreateKeys().Pin.Keys(), ","); got != " ,=" {
+		t.Errorf("failed Apply changed Pin keys to %q", got)
+	}
+}
@@ -0,0 +1,75 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// DetailKeyMap defines the keybindings of the element detail view.
+type DetailKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	NextTab key.Binding
+	PrevTab key.Binding
+	Back    key.Binding
+	Help    key.Binding
+	Quit    key.Binding
+
+	RaiseCharge key.Binding
+	LowerCharge key.Binding
+}
+
+func (k DetailKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.PrevTab, k.NextTab, k.Up, k.Down, k.RaiseCharge, k.LowerCharge, k.Back, k.Help, k.Quit}
+}
+
+func (k DetailKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.PrevTab, k.NextTab},
+		{k.Up, k.Down},
+		{k.RaiseCharge, k.LowerCharge},
+		{k.Back, k.Help, k.Quit},
+	}
+}
+
+var detailKeys = DetailKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "scroll up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "scroll down"),
+	),
+	NextTab: key.NewBinding(
+		key.WithKeys("tab", "right", "l"),
+		key.WithHelp("tab/→", "next tab"),
+	),
+	PrevTab: key.NewBinding(
+		key.WithKeys("shift+tab", "left", "h"),
+		key.WithHelp("shift+tab/←", "previous tab"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc", "backspace"),
+		key.WithHelp("esc", "back to table"),
+	),
+	RaiseCharge: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "raise ion charge"),
+	),
+	LowerCharge: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "lower ion charge"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "toggle help"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q/ctrl+c", "quit"),
+	),
+}
+
+func CreateDetailKeys() DetailKeyMap {
+	return detailKeys
+}
@@ -7,13 +7,51 @@ import (
 // KeyMap defines a set of keybindings. To work for help it must satisfy
 // key.Map. It could also very easily be a map[string]key.Binding.
 type KeyMap struct {
-	Up     key.Binding
-	Down   key.Binding
-	Left   key.Binding
-	Right  key.Binding
-	Help   key.Binding
-	Quit   key.Binding
-	Search key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Left    key.Binding
+	Right   key.Binding
+	Help    key.Binding
+	Quit    key.Binding
+	Search  key.Binding
+	Filter  key.Binding
+	List    key.Binding
+	Details key.Binding
+	Confirm key.Binding
+	Cancel  key.Binding
+
+	Pin       key.Binding
+	ClearPins key.Binding
+	Compare   key.Binding
+
+	Calculator key.Binding
+
+	AddToCompound      key.Binding
+	OpenGroup          key.Binding
+	CloseGroup         key.Binding
+	DeleteFromCompound key.Binding
+
+	NextScheme   key.Binding
+	PrevScheme   key.Binding
+	Heatmap      key.Binding
+	HeatmapScale key.Binding
+
+	Legend         key.Binding
+	ChooseCategory key.Binding
+
+	NextType   key.Binding
+	PrevType   key.Binding
+	NextGroup  key.Binding
+	PrevGroup  key.Binding
+	NextPeriod key.Binding
+	PrevPeriod key.Binding
+	NextBlock  key.Binding
+	PrevBlock  key.Binding
+	GoTo       key.Binding
+	NextMatch  key.Binding
+	PrevMatch  key.Binding
+	NextResult key.Binding
+	PrevResult key.Binding
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
@@ -25,7 +63,14 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right}, // first column
-		{k.Help, k.Quit},                // second column
+		{k.NextType, k.PrevType, k.NextGroup, k.PrevGroup},
+		{k.NextPeriod, k.PrevPeriod, k.NextBlock, k.PrevBlock},
+		{k.GoTo, k.Search, k.NextMatch, k.PrevMatch, k.NextResult, k.PrevResult},
+		{k.Filter, k.Confirm, k.Cancel},
+		{k.NextScheme, k.PrevScheme, k.Heatmap, k.HeatmapScale, k.Legend, k.ChooseCategory},
+		{k.Pin, k.ClearPins, k.Compare, k.Calculator},
+		{k.AddToCompound, k.OpenGroup, k.CloseGroup, k.DeleteFromCompound},
+		{k.Details, k.List, k.Help, k.Quit},
 	}
 }
 
@@ -51,13 +96,141 @@ var keys = KeyMap{
 		key.WithHelp("?", "toggle help"),
 	),
 	Quit: key.NewBinding(
-		key.WithKeys("q", "esc", "ctrl+c"),
-		key.WithHelp("q", "quit"),
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q/ctrl+c", "quit"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "accept search/filter"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "clear search/close"),
 	),
 	Search: key.NewBinding(
 		key.WithKeys("/"),
 		key.WithHelp("/", "search"),
 	),
+	Filter: key.NewBinding(
+		key.WithKeys(":"),
+		key.WithHelp(":", "filter"),
+	),
+	List: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "list view"),
+	),
+	Details: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "element details"),
+	),
+	Legend: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "focus legend"),
+	),
+	ChooseCategory: key.NewBinding(
+		key.WithKeys("enter", " "),
+		key.WithHelp("enter", "highlight category"),
+	),
+	Pin: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pin/unpin for comparison"),
+	),
+	ClearPins: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "clear pins"),
+	),
+	Compare: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "compare pinned"),
+	),
+	Calculator: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "formula calculator"),
+	),
+	AddToCompound: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "add to compound, then 0-9 for its count"),
+	),
+	OpenGroup: key.NewBinding(
+		key.WithKeys("("),
+		key.WithHelp("(", "open group in compound"),
+	),
+	CloseGroup: key.NewBinding(
+		key.WithKeys(")"),
+		key.WithHelp(")", "close group in compound"),
+	),
+	DeleteFromCompound: key.NewBinding(
+		key.WithKeys("backspace"),
+		key.WithHelp("backspace", "delete from compound"),
+	),
+	NextScheme: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "next color scheme"),
+	),
+	PrevScheme: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "previous color scheme"),
+	),
+	Heatmap: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "cycle heatmap property"),
+	),
+	HeatmapScale: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "toggle linear/log heatmap"),
+	),
+	NextType: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "next of same type"),
+	),
+	PrevType: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "previous of same type"),
+	),
+	NextGroup: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "next in group"),
+	),
+	PrevGroup: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "previous in group"),
+	),
+	NextPeriod: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "next in period"),
+	),
+	PrevPeriod: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "previous in period"),
+	),
+	NextBlock: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "next in block"),
+	),
+	PrevBlock: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "previous in block"),
+	),
+	GoTo: key.NewBinding(
+		key.WithKeys("G"),
+		key.WithHelp("[n]G", "go to atomic number n"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next search result"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous search result"),
+	),
+	NextResult: key.NewBinding(
+		key.WithKeys("down", "ctrl+n"),
+		key.WithHelp("↓/ctrl+n", "next result while typing"),
+	),
+	PrevResult: key.NewBinding(
+		key.WithKeys("up", "ctrl+p"),
+		key.WithHelp("↑/ctrl+p", "previous result while typing"),
+	),
 }
 
 func CreateKeys() KeyMap {
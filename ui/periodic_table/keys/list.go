@@ -0,0 +1,118 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// ListKeyMap defines the keybindings of the list view.
+type ListKeyMap struct {
+	Up          key.Binding
+	Down        key.Binding
+	PageUp      key.Binding
+	PageDown    key.Binding
+	Top         key.Binding
+	Bottom      key.Binding
+	PrevColumn  key.Binding
+	NextColumn  key.Binding
+	Sort        key.Binding
+	Filter      key.Binding
+	Columns     key.Binding
+	ToggleField key.Binding
+	Select      key.Binding
+	Confirm     key.Binding
+	Cancel      key.Binding
+	Back        key.Binding
+	Help        key.Binding
+	Quit        key.Binding
+}
+
+func (k ListKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.PrevColumn, k.NextColumn, k.Sort, k.Filter, k.Columns, k.Select, k.Back, k.Help, k.Quit}
+}
+
+func (k ListKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.PageUp, k.PageDown, k.Top, k.Bottom},
+		{k.PrevColumn, k.NextColumn, k.Sort, k.Filter, k.Confirm, k.Cancel},
+		{k.Columns, k.ToggleField, k.Select, k.Back, k.Help, k.Quit},
+	}
+}
+
+var listKeys = ListKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "move up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "move down"),
+	),
+	PageUp: key.NewBinding(
+		key.WithKeys("pgup", "ctrl+u"),
+		key.WithHelp("pgup", "page up"),
+	),
+	PageDown: key.NewBinding(
+		key.WithKeys("pgdown", "ctrl+d"),
+		key.WithHelp("pgdn", "page down"),
+	),
+	Top: key.NewBinding(
+		key.WithKeys("home", "g"),
+		key.WithHelp("g", "first row"),
+	),
+	Bottom: key.NewBinding(
+		key.WithKeys("end", "G"),
+		key.WithHelp("G", "last row"),
+	),
+	PrevColumn: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "previous column"),
+	),
+	NextColumn: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "next column"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "sort by column"),
+	),
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"),
+	),
+	Columns: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "choose columns"),
+	),
+	ToggleField: key.NewBinding(
+		key.WithKeys(" ", "x"),
+		key.WithHelp("space", "show/hide column"),
+	),
+	Select: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "show in table"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "keep filter"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "remove filter"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("L", "esc"),
+		key.WithHelp("L/esc", "back to table"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "toggle help"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q/ctrl+c", "quit"),
+	),
+}
+
+func CreateListKeys() ListKeyMap {
+	return listKeys
+}
@@ -0,0 +1,298 @@
+// Package list shows every element as a row of a sortable, filterable table.
+package list
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/views"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	browseMode = iota
+	filterMode
+	columnsMode
+)
+
+// reservedHeight is the number of lines used by the header and the bars below
+// the rows.
+const reservedHeight = 4
+
+var defaultColumns = []string{
+	"atomic_number",
+	"symbol",
+	"name",
+	"atomic_mass",
+	"type",
+	"phase",
+	"electronegativity",
+	"density",
+	"melting_point",
+	"boiling_point",
+}
+
+type model struct {
+	data    []element.Data
+	rows    []element.Data
+	columns []element.Field
+
+	focused    int
+	sortField  string
+	descending bool
+
+	cursor, offset int
+	columnCursor   int
+	width, height  int
+
+	state  int
+	filter textinput.Model
+	keys   keys.ListKeyMap
+	help   help.Model
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.help.Width = msg.Width
+		m.scrollToCursor()
+	case views.OpenListMsg:
+		m.selectElement(msg.Element)
+	case views.ConfigMsg:
+		m.keys = keys.CreateListKeys()
+	case tea.KeyMsg:
+		switch m.state {
+		case filterMode:
+			cmd = m.updateFilter(msg)
+		case columnsMode:
+			m.updateColumns(msg)
+		default:
+			cmd = m.updateBrowse(msg)
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *model) updateBrowse(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return tea.Quit
+	case key.Matches(msg, m.keys.Help):
+		m.help.ShowAll = !m.help.ShowAll
+		m.scrollToCursor()
+	case key.Matches(msg, m.keys.Up):
+		m.moveCursor(-1)
+	case key.Matches(msg, m.keys.Down):
+		m.moveCursor(1)
+	case key.Matches(msg, m.keys.PageUp):
+		m.moveCursor(-m.pageSize())
+	case key.Matches(msg, m.keys.PageDown):
+		m.moveCursor(m.pageSize())
+	case key.Matches(msg, m.keys.Top):
+		m.moveCursor(-len(m.rows))
+	case key.Matches(msg, m.keys.Bottom):
+		m.moveCursor(len(m.rows))
+	case key.Matches(msg, m.keys.PrevColumn):
+		m.focused = (m.focused - 1 + len(m.columns)) % len(m.columns)
+	case key.Matches(msg, m.keys.NextColumn):
+		m.focused = (m.focused + 1) % len(m.columns)
+	case key.Matches(msg, m.keys.Sort):
+		m.sortByFocused()
+	case key.Matches(msg, m.keys.Filter):
+		m.state = filterMode
+		return m.filter.Focus()
+	case key.Matches(msg, m.keys.Columns):
+		m.state = columnsMode
+	case key.Matches(msg, m.keys.Select), key.Matches(msg, m.keys.Back):
+		if selected, ok := m.selected(); ok {
+			return views.Open(views.OpenTableMsg{Element: selected})
+		}
+		return views.Open(views.OpenTableMsg{})
+	}
+	return nil
+}
+
+// updateFilter narrows the rows down as the filter is typed. Enter keeps the
+// filter and esc removes it.
+func (m *model) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case msg.Type != tea.KeyRunes && key.Matches(msg, m.keys.Quit):
+		return tea.Quit
+	case key.Matches(msg, m.keys.Confirm):
+		m.state = browseMode
+		m.filter.Blur()
+		return nil
+	case key.Matches(msg, m.keys.Cancel):
+		m.state = browseMode
+		m.filter.Blur()
+		m.filter.Reset()
+		m.refresh()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	m.refresh()
+	return cmd
+}
+
+func (m *model) updateColumns(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.columnCursor = (m.columnCursor - 1 + len(element.Fields)) % len(element.Fields)
+	case key.Matches(msg, m.keys.Down):
+		m.columnCursor = (m.columnCursor + 1) % len(element.Fields)
+	case key.Matches(msg, m.keys.ToggleField):
+		m.toggleColumn(element.Fields[m.columnCursor])
+	case key.Matches(msg, m.keys.Columns), key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Select):
+		m.state = browseMode
+	}
+}
+
+// toggleColumn shows or hides field, keeping the columns in the order of
+// element.Fields. The last column cannot be hidden.
+func (m *model) toggleColumn(field element.Field) {
+	shown := m.isShown(field)
+	if shown && len(m.columns) == 1 {
+		return
+	}
+
+	var columns []element.Field
+	for _, f := range element.Fields {
+		if f.Name == field.Name && shown {
+			continue
+		}
+		if f.Name == field.Name || m.isShown(f) {
+			columns = append(columns, f)
+		}
+	}
+
+	m.columns = columns
+	if m.focused >= len(m.columns) {
+		m.focused = len(m.columns) - 1
+	}
+	m.refresh()
+}
+
+func (m model) isShown(field element.Field) bool {
+	for _, c := range m.columns {
+		if c.Name == field.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *model) sortByFocused() {
+	field := m.columns[m.focused]
+	if m.sortField == field.Name {
+		m.descending = !m.descending
+	} else {
+		m.sortField = field.Name
+		m.descending = false
+	}
+	m.refresh()
+}
+
+// refresh filters and sorts the rows again, keeping the selected element
+// under the cursor if it is still shown.
+func (m *model) refresh() {
+	selected, hadSelection := m.selected()
+
+	m.rows = filterRows(m.data, m.columns, m.filter.Value())
+	if field, ok := element.FieldByName(m.sortField); ok {
+		element.Sort(m.rows, field, m.descending)
+	}
+
+	m.cursor = 0
+	if hadSelection {
+		m.selectElement(selected)
+	}
+	m.scrollToCursor()
+}
+
+func (m *model) selectElement(d element.Data) {
+	for i, row := range m.rows {
+		if row.AtomicNumber == d.AtomicNumber {
+			m.cursor = i
+			break
+		}
+	}
+	m.scrollToCursor()
+}
+
+func (m model) selected() (element.Data, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return element.Data{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+func (m *model) moveCursor(step int) {
+	m.cursor += step
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.scrollToCursor()
+}
+
+func (m model) pageSize() int {
+	helpHeight := lipgloss.Height(m.help.View(m.keys)) - 1
+	if size := m.height - reservedHeight - helpHeight; size > 1 {
+		return size
+	}
+	return 1
+}
+
+func (m *model) scrollToCursor() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.pageSize() {
+		m.offset = m.cursor - m.pageSize() + 1
+	}
+	if last := len(m.rows) - m.pageSize(); m.offset > last {
+		m.offset = last
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+func CreateModel(data []element.Data) tea.Model {
+	filter := textinput.New()
+	filter.Prompt = "Filter: "
+
+	var columns []element.Field
+	for _, name := range defaultColumns {
+		if field, ok := element.FieldByName(name); ok {
+			columns = append(columns, field)
+		}
+	}
+
+	m := model{
+		data:      data,
+		columns:   columns,
+		sortField: "atomic_number",
+		filter:    filter,
+		keys:      keys.CreateListKeys(),
+		help:      help.New(),
+	}
+	m.refresh()
+	return m
+}
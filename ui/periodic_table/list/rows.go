@@ -0,0 +1,29 @@
+package list
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+)
+
+// filterRows returns the elements where any of the columns contains text,
+// ignoring case.
+func filterRows(data []element.Data, columns []element.Field, text string) []element.Data {
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	var rows []element.Data
+	for _, d := range data {
+		if text == "" || rowContains(d, columns, text) {
+			rows = append(rows, d)
+		}
+	}
+	return rows
+}
+
+func rowContains(d element.Data, columns []element.Field, text string) bool {
+	for _, c := range columns {
+		if strings.Contains(strings.ToLower(c.Text(d)), text) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,10 @@
+package list
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	headerStyle        = lipgloss.NewStyle().Bold(true)
+	focusedHeaderStyle = headerStyle.Copy().Underline(true)
+	selectedRowStyle   = lipgloss.NewStyle().Reverse(true)
+	missingStyle       = lipgloss.NewStyle().Faint(true)
+)
@@ -0,0 +1,124 @@
+package list
+
+import (
+	"fmt"
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	maxColumnWidth = 24
+	missingValue   = "—"
+)
+
+func (m model) View() string {
+	if m.state == columnsMode {
+		return lipgloss.JoinVertical(0, m.columnsView(), m.help.View(m.keys))
+	}
+
+	widths := m.columnWidths()
+	lines := []string{m.headerView(widths)}
+
+	for i := m.offset; i < len(m.rows) && i < m.offset+m.pageSize(); i++ {
+		lines = append(lines, m.rowView(m.rows[i], widths, i == m.cursor))
+	}
+	for len(lines) < m.pageSize()+1 {
+		lines = append(lines, "")
+	}
+
+	status := fmt.Sprintf("%d of %d elements", len(m.rows), len(m.data))
+	if m.state == filterMode || m.filter.Value() != "" {
+		status = m.filter.View() + "  " + status
+	}
+
+	return lipgloss.JoinVertical(0, strings.Join(lines, "\n"), "", status, m.help.View(m.keys))
+}
+
+func (m model) columnWidths() []int {
+	var widths []int
+	for _, c := range m.columns {
+		width := lipgloss.Width(c.Label) + 2
+		for _, d := range m.rows {
+			if w := lipgloss.Width(c.Text(d)); w > width {
+				width = w
+			}
+		}
+		if width > maxColumnWidth {
+			width = maxColumnWidth
+		}
+		widths = append(widths, width)
+	}
+	return widths
+}
+
+func (m model) headerView(widths []int) string {
+	var cells []string
+	for i, c := range m.columns {
+		title := c.Label
+		if c.Name == m.sortField {
+			if m.descending {
+				title += " ▼"
+			} else {
+				title += " ▲"
+			}
+		}
+
+		style := headerStyle
+		if i == m.focused {
+			style = focusedHeaderStyle
+		}
+		cells = append(cells, style.Render(cell(title, widths[i], c.Kind == element.Number)))
+	}
+	return strings.Join(cells, " ")
+}
+
+func (m model) rowView(d element.Data, widths []int, isSelected bool) string {
+	var cells []string
+	for i, c := range m.columns {
+		value := c.Text(d)
+		text := cell(value, widths[i], c.Kind == element.Number)
+		if value == "" {
+			text = missingStyle.Render(cell(missingValue, widths[i], c.Kind == element.Number))
+		}
+		cells = append(cells, text)
+	}
+
+	row := strings.Join(cells, " ")
+	if isSelected {
+		return selectedRowStyle.Render(row)
+	}
+	return row
+}
+
+// cell pads or truncates text to width, aligning numbers to the right.
+func cell(text string, width int, alignRight bool) string {
+	if lipgloss.Width(text) > width {
+		runes := []rune(text)
+		text = string(runes[:width-1]) + "…"
+	}
+
+	padding := strings.Repeat(" ", width-lipgloss.Width(text))
+	if alignRight {
+		return padding + text
+	}
+	return text + padding
+}
+
+func (m model) columnsView() string {
+	lines := []string{headerStyle.Render("Columns")}
+	for i, f := range element.Fields {
+		check := "[ ]"
+		if m.isShown(f) {
+			check = "[x]"
+		}
+
+		line := fmt.Sprintf("%s %s", check, f.Label)
+		if i == m.columnCursor {
+			line = selectedRowStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
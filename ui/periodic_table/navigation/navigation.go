@@ -0,0 +1,82 @@
+// Package navigation moves the selection of a grid of elements to related
+// elements, as the table and the accessible view both do.
+package navigation
+
+import (
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/keys"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Property is what related elements share, such as their group.
+type Property func(d element.Data) string
+
+func Type(d element.Data) string   { return d.Type }
+func Group(d element.Data) string  { return d.Group }
+func Period(d element.Data) string { return d.Period }
+func Block(d element.Data) string  { return d.Block() }
+
+// Related returns the property shared with the element msg moves to, and
+// whether it moves backwards. It returns false if msg is no key of k moving
+// to a related element.
+func Related(k keys.KeyMap, msg tea.KeyMsg) (Property, bool, bool) {
+	switch {
+	case key.Matches(msg, k.NextType):
+		return Type, false, true
+	case key.Matches(msg, k.PrevType):
+		return Type, true, true
+	case key.Matches(msg, k.NextGroup):
+		return Group, false, true
+	case key.Matches(msg, k.PrevGroup):
+		return Group, true, true
+	case key.Matches(msg, k.NextPeriod):
+		return Period, false, true
+	case key.Matches(msg, k.PrevPeriod):
+		return Period, true, true
+	case key.Matches(msg, k.NextBlock):
+		return Block, false, true
+	case key.Matches(msg, k.PrevBlock):
+		return Block, true, true
+	}
+	return nil, false, false
+}
+
+// SelectRelated moves to the next element which shares property with the
+// active element. It returns false if there is none.
+func SelectRelated(g *grid.Model, property Property, reverse bool) bool {
+	active, ok := ActiveElement(g)
+	if !ok || property(active) == "" {
+		return false
+	}
+
+	value := property(active)
+	return g.SelectNext(func(c grid.Cell) bool {
+		d, ok := c.GetData().(element.Data)
+		return ok && property(d) == value
+	}, reverse)
+}
+
+// GoTo selects the element with the given atomic number. It returns false if
+// there is none.
+func GoTo(g *grid.Model, number int) bool {
+	target := strconv.Itoa(number)
+	return g.SelectFirst(func(c grid.Cell) bool {
+		d, ok := c.GetData().(element.Data)
+		return ok && d.AtomicNumber == target
+	})
+}
+
+// ActiveElement returns the element selected in g.
+func ActiveElement(g *grid.Model) (element.Data, bool) {
+	cell := g.GetActiveCell()
+	if cell == nil {
+		return element.Data{}, false
+	}
+
+	d, ok := (*cell).GetData().(element.Data)
+	return d, ok
+}
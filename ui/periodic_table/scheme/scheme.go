@@ -0,0 +1,295 @@
+// Package scheme defines how elements are colored in the table.
+package scheme
+
+import (
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/theme"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Scheme decides the color each element is drawn in and explains it with a
+// legend.
+type Scheme interface {
+	Name() string
+	// Color returns false if d has no value the scheme can color by.
+	Color(d element.Data) (lipgloss.TerminalColor, bool)
+	// Code returns a short code standing in for the color of d on terminals
+	// without color, or an empty string if d has no value.
+	Code(d element.Data) string
+	Legend(width int) string
+}
+
+// Category is one value of a categorical scheme. The schemes define Color
+// for dark terminals, Categories returns it in the colors of the theme. Code
+// is a lower case letter code shown instead of the color in the plain
+// profile, lower case so it isn't mistaken for an element symbol.
+type Category struct {
+	Value string
+	Label string
+	Code  string
+	Color lipgloss.TerminalColor
+}
+
+// Key returns the swatch of the category, or its code in brackets in the
+// plain profile.
+func (c Category) Key() string {
+	if theme.IsPlain() {
+		return "[" + c.Code + "]"
+	}
+	return Swatch(c.Color)
+}
+
+// Categorical colors elements by a property with a handful of values.
+type Categorical struct {
+	name       string
+	value      func(d element.Data) string
+	categories []Category
+}
+
+func (c Categorical) Name() string {
+	return c.name
+}
+
+// ID returns the short lower case name the scheme is chosen by in settings,
+// such as "phase".
+func (c Categorical) ID() string {
+	return strings.ToLower(strings.Fields(c.name)[0])
+}
+
+// Value returns the category value of d.
+func (c Categorical) Value(d element.Data) string {
+	return c.value(d)
+}
+
+// Categories returns the categories of the scheme in legend order, in the
+// colors of the current theme.
+func (c Categorical) Categories() []Category {
+	t := theme.Current()
+	categories := make([]Category, len(c.categories))
+	for i, category := range c.categories {
+		base, _ := category.Color.(lipgloss.Color)
+		category.Color = t.Category(c.ID(), category.Value, base)
+		categories[i] = category
+	}
+	return categories
+}
+
+func (c Categorical) Color(d element.Data) (lipgloss.TerminalColor, bool) {
+	value := c.value(d)
+	for _, category := range c.Categories() {
+		if strings.EqualFold(category.Value, value) {
+			return category.Color, true
+		}
+	}
+	return nil, false
+}
+
+func (c Categorical) Code(d element.Data) string {
+	value := c.value(d)
+	for _, category := range c.categories {
+		if strings.EqualFold(category.Value, value) {
+			return category.Code
+		}
+	}
+	return ""
+}
+
+// Legend lists a swatch for every category, wrapping onto new lines to fit
+// width.
+func (c Categorical) Legend(width int) string {
+	var labels []string
+	for _, category := range c.Categories() {
+		labels = append(labels, category.Key()+" "+category.Label)
+	}
+	return lipgloss.JoinVertical(0, c.name, Wrap(labels, width))
+}
+
+// Counts returns how many of data fall into each category, keyed by value.
+func (c Categorical) Counts(data []element.Data) map[string]int {
+	counts := map[string]int{}
+	for _, d := range data {
+		value := c.value(d)
+		for _, category := range c.categories {
+			if strings.EqualFold(category.Value, value) {
+				counts[category.Value]++
+			}
+		}
+	}
+	return counts
+}
+
+// Swatch renders a small block in color.
+func Swatch(color lipgloss.TerminalColor) string {
+	return lipgloss.NewStyle().Foreground(color).Render("■")
+}
+
+// Wrap joins items with spacing, starting a new line whenever the next item
+// wouldn't fit in width.
+func Wrap(items []string, width int) string {
+	const gap = "   "
+
+	var lines []string
+	var line string
+	for _, item := range items {
+		if line != "" && lipgloss.Width(line+gap+item) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += gap
+		}
+		line += item
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func flag(value string) func(d element.Data) string {
+	field, _ := element.FieldByName(value)
+	return func(d element.Data) string {
+		if field.IsSet(d) {
+			return "yes"
+		}
+		return "no"
+	}
+}
+
+// metallicity returns the first of the metal, metalloid and nonmetal flags
+// which is set for d.
+func metallicity(d element.Data) string {
+	for _, name := range []string{"metal", "metalloid", "nonmetal"} {
+		if field, _ := element.FieldByName(name); field.IsSet(d) {
+			return name
+		}
+	}
+	return ""
+}
+
+// typeOrder is the order categories appear in the legend of the Type scheme.
+var typeOrder = []string{
+	"Alkali Metal",
+	"Alkaline Earth Metal",
+	"Transition Metal",
+	"Metal",
+	"Metalloid",
+	"Nonmetal",
+	"Halogen",
+	"Noble Gas",
+	"Lanthanide",
+	"Actinide",
+	"Transactinide",
+}
+
+// typeCodes are the codes of the element types, following the Ln and An
+// chemists write for lanthanides and actinides.
+var typeCodes = map[string]string{
+	"Alkali Metal":         "ak",
+	"Alkaline Earth Metal": "ae",
+	"Transition Metal":     "tm",
+	"Metal":                "pt",
+	"Metalloid":            "md",
+	"Nonmetal":             "nm",
+	"Halogen":              "hl",
+	"Noble Gas":            "ng",
+	"Lanthanide":           "ln",
+	"Actinide":             "an",
+	"Transactinide":        "ta",
+}
+
+func typeCategories() []Category {
+	var categories []Category
+	for _, t := range typeOrder {
+		categories = append(categories, Category{Value: t, Label: t, Code: typeCodes[t], Color: element.TypeColors[t]})
+	}
+	return categories
+}
+
+var (
+	Type = Categorical{
+		name:       "Type",
+		value:      func(d element.Data) string { return d.Type },
+		categories: typeCategories(),
+	}
+	Block = Categorical{
+		name:  "Block",
+		value: func(d element.Data) string { return d.Block() },
+		categories: []Category{
+			{"s", "s-block", "s", lipgloss.Color("#d1605e")},
+			{"p", "p-block", "p", lipgloss.Color("#e5b75b")},
+			{"d", "d-block", "d", lipgloss.Color("#4b93c6")},
+			{"f", "f-block", "f", lipgloss.Color("#1aa29d")},
+		},
+	}
+	Phase = Categorical{
+		name:  "Phase at STP",
+		value: func(d element.Data) string { return d.Phase },
+		categories: []Category{
+			{"solid", "Solid", "s", lipgloss.Color("#8a8fa3")},
+			{"liquid", "Liquid", "l", lipgloss.Color("#4b93c6")},
+			{"gas", "Gas", "g", lipgloss.Color("#e4a54d")},
+			{"artificial", "Artificial", "a", lipgloss.Color("#f9c857")},
+		},
+	}
+	Origin = Categorical{
+		name:  "Origin",
+		value: flag("natural"),
+		categories: []Category{
+			{"yes", "Natural", "n", lipgloss.Color("#5aa469")},
+			{"no", "Synthetic", "sy", lipgloss.Color("#c778a5")},
+		},
+	}
+	Metallicity = Categorical{
+		name:  "Metallicity",
+		value: metallicity,
+		categories: []Category{
+			{"metal", "Metal", "m", lipgloss.Color("#4b93c6")},
+			{"metalloid", "Metalloid", "md", lipgloss.Color("#e4a54d")},
+			{"nonmetal", "Nonmetal", "nm", lipgloss.Color("#cf53a4")},
+		},
+	}
+	Radioactivity = Categorical{
+		name:  "Radioactivity",
+		value: flag("radioactive"),
+		categories: []Category{
+			{"yes", "Radioactive", "r", lipgloss.Color("#d6d03b")},
+			{"no", "Stable", "st", lipgloss.Color("#697a90")},
+		},
+	}
+)
+
+// Schemes are the categorical schemes in the order they are cycled through.
+var Schemes = []Categorical{Type, Block, Phase, Origin, Metallicity, Radioactivity}
+
+// Apply styles every element among cells with the colors and codes of s.
+func Apply(s Scheme, cells []grid.Cell) {
+	for _, c := range cells {
+		d, ok := c.GetData().(element.Data)
+		if !ok || c.IsPaddingCell() {
+			continue
+		}
+
+		if color, ok := s.Color(d); ok {
+			c.SetStyle(element.Styles(color))
+		} else {
+			c.SetStyle(element.NoDataStyles())
+		}
+		if e, ok := c.(*element.Element); ok {
+			e.SetCode(s.Code(d))
+		}
+	}
+}
+
+// Find returns the index in Schemes of the scheme with the given ID.
+func Find(id string) (int, bool) {
+	for i, s := range Schemes {
+		if s.ID() == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
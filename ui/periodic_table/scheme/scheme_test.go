@@ -0,0 +1,90 @@
+package scheme
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"testing"
+)
+
+func TestCategorical(t *testing.T) {
+	tests := []struct {
+		scheme Categorical
+		data   element.Data
+		code   string
+	}{
+		{Type, element.Data{Type: "Noble Gas"}, "ng"},
+		{Type, element.Data{Type: "alkali metal"}, "ak"},
+		{Type, element.Data{Type: "Transactinide"}, "ta"},
+		{Type, element.Data{Type: ""}, ""},
+		{Type, element.Data{Type: "Superheavy"}, ""},
+		{Block, element.Data{Group: "1", Period: "3"}, "s"},
+		{Block, element.Data{Group: "18", Period: "1"}, "s"},
+		{Block, element.Data{Group: "18", Period: "2"}, "p"},
+		{Block, element.Data{Group: "8", Period: "4"}, "d"},
+		{Block, element.Data{Type: "Lanthanide", Period: "6"}, "f"},
+		{Block, element.Data{}, ""},
+		{Phase, element.Data{Phase: "gas"}, "g"},
+		{Phase, element.Data{Phase: "Liquid"}, "l"},
+		{Phase, element.Data{Phase: "artificial"}, "a"},
+		{Phase, element.Data{Phase: ""}, ""},
+		{Origin, element.Data{Natural: "yes"}, "n"},
+		{Origin, element.Data{Natural: "YES"}, "n"},
+		{Origin, element.Data{Natural: "no"}, "sy"},
+		{Origin, element.Data{Natural: ""}, "sy"},
+		{Metallicity, element.Data{Metal: "yes"}, "m"},
+		{Metallicity, element.Data{Metalloid: "yes"}, "md"},
+		{Metallicity, element.Data{Nonmetal: "yes"}, "nm"},
+		{Metallicity, element.Data{Metal: "Yes"}, "m"},
+		{Metallicity, element.Data{Metalloid: "YES"}, "md"},
+		{Metallicity, element.Data{Metal: "no", Nonmetal: "no"}, ""},
+		{Radioactivity, element.Data{Radioactive: "yes"}, "r"},
+		{Radioactivity, element.Data{Radioactive: ""}, "st"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme.ID()+"/"+tt.code, func(t *testing.T) {
+			if got := tt.scheme.Code(tt.data); got != tt.code {
+				t.Errorf("Code() = %q, want %q", got, tt.code)
+			}
+
+			color, ok := tt.scheme.Color(tt.data)
+			if tt.code == "" {
+				if ok {
+					t.Errorf("Color() = %v, want no color", color)
+				}
+				return
+			}
+			for _, category := range tt.scheme.Categories() {
+				if category.Code == tt.code && color != category.Color {
+					t.Errorf("Color() = %v, want %v of %s", color, category.Color, category.Label)
+				}
+			}
+		})
+	}
+}
+
+func TestCategorical_Counts(t *testing.T) {
+	data := []element.Data{{Phase: "gas"}, {Phase: "Gas"}, {Phase: "solid"}, {Phase: "plasma"}, {}}
+	counts := Phase.Counts(data)
+	if counts["gas"] != 2 || counts["solid"] != 1 || counts["liquid"] != 0 || len(counts) != 2 {
+		t.Errorf("Counts() = %v", counts)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		items []string
+		width int
+		want  string
+	}{
+		{nil, 20, ""},
+		{[]string{"one", "two", "three"}, 20, "one   two   three"},
+		{[]string{"one", "two", "three"}, 17, "one   two   three"},
+		{[]string{"one", "two", "three"}, 16, "one   two\nthree"},
+		{[]string{"one", "two", "three"}, 2, "one\ntwo\nthree"},
+		{[]string{"■ Gas", "■ Solid"}, 15, "■ Gas   ■ Solid"},
+	}
+	for _, tt := range tests {
+		if got := Wrap(tt.items, tt.width); got != tt.want {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.items, tt.width, got, tt.want)
+		}
+	}
+}
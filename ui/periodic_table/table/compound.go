@@ -0,0 +1,130 @@
+package table
+
+import (
+	"fmt"
+	"periodic-table/src/formula"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lastElement matches the element at the end of a compound and its count.
+var lastElement = regexp.MustCompile(`([A-Z][a-z]?)([0-9]*)$`)
+
+// addToCompound adds the active element to the compound being built. Adding
+// the element which was added last counts it once more instead.
+func (m *model) addToCompound() {
+	active, ok := m.activeElement()
+	if !ok {
+		return
+	}
+
+	symbol := strings.TrimSpace(active.Symbol)
+	if match := lastElement.FindStringSubmatch(m.compound); match != nil && match[1] == symbol {
+		count := 1
+		if match[2] != "" {
+			count, _ = strconv.Atoi(match[2])
+		}
+		m.compound = strings.TrimSuffix(m.compound, match[0]) + symbol + strconv.Itoa(count+1)
+		return
+	}
+	m.compound += symbol
+}
+
+// countCompound types a digit of the count of the last element or group of
+// the compound. It reports false if no compound is being built, when digits
+// are a count prefix instead.
+func (m *model) countCompound(digit string) bool {
+	if m.compound == "" {
+		return false
+	}
+	last := m.compound[len(m.compound)-1]
+	if last == '(' || (digit == "0" && (last < '0' || last > '9')) {
+		return true
+	}
+	m.compound += digit
+	return true
+}
+
+func (m *model) openGroup() {
+	m.compound += "("
+}
+
+// closeGroup closes the innermost open group, unless it is empty.
+func (m *model) closeGroup() {
+	if openGroups(m.compound) > 0 && !strings.HasSuffix(m.compound, "(") {
+		m.compound += ")"
+	}
+}
+
+// deleteFromCompound removes the last digit, parenthesis or element of the
+// compound.
+func (m *model) deleteFromCompound() {
+	if m.compound == "" {
+		return
+	}
+	if match := lastElement.FindStringSubmatch(m.compound); match != nil && match[2] == "" {
+		m.compound = strings.TrimSuffix(m.compound, match[1])
+		return
+	}
+	m.compound = m.compound[:len(m.compound)-1]
+}
+
+func openGroups(compound string) int {
+	return strings.Count(compound, "(") - strings.Count(compound, ")")
+}
+
+// closedCompound returns the compound with its open groups closed, leaving
+// out groups opened last which are still empty.
+func (m model) closedCompound() string {
+	compound := strings.TrimRight(m.compound, "(")
+	return compound + strings.Repeat(")", openGroups(compound))
+}
+
+// compoundView shows the compound being built with its molar mass and
+// composition, and whether common oxidation states of its elements make it
+// neutral.
+func (m model) compoundView() string {
+	if m.compound == "" {
+		return ""
+	}
+
+	written := formula.Subscript(m.compound)
+	if openGroups(m.compound) > 0 {
+		written += legendHintStyle.Render(strings.Repeat(")", openGroups(m.compound)))
+	}
+	f, err := formula.Parse(m.closedCompound(), m.data)
+	if err != nil || len(f.Atoms) == 0 {
+		return "Compound: " + written
+	}
+
+	parts := []string{"Compound: " + written, fmt.Sprintf("%.3f g/mol", f.MolarMass())}
+	for _, s := range f.Composition() {
+		parts = append(parts, fmt.Sprintf("%s %.2f%%", s.Symbol(), s.Percent))
+	}
+	return lipgloss.JoinVertical(0, strings.Join(parts, "  "), neutralityView(f))
+}
+
+// neutralityView tells whether the formula can be neutral with the common
+// oxidation states of its elements, and with which.
+func neutralityView(f formula.Formula) string {
+	states, ok := f.OxidationStates()
+	if !ok {
+		var symbols []string
+		for _, a := range f.Atoms {
+			symbols = append(symbols, a.Symbol())
+		}
+		return errorStyle().Render("Not neutral: no common oxidation states of " + strings.Join(symbols, ", ") + " add up to 0")
+	}
+	if len(f.Atoms) == 1 {
+		return "Neutral: an element, oxidation state 0"
+	}
+
+	var assigned []string
+	for i, a := range f.Atoms {
+		assigned = append(assigned, fmt.Sprintf("%s %+d", a.Symbol(), states[i]))
+	}
+	return "Neutral: " + strings.Join(assigned, ", ")
+}
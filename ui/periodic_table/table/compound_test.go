@@ -0,0 +1,122 @@
+package table
+
+import (
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/element"
+	"testing"
+)
+
+func TestAddToCompound(t *testing.T) {
+	tests := []struct {
+		compound string
+		symbol   string
+		want     string
+	}{
+		{"", "H", "H"},
+		{"H", "H", "H2"},
+		{"H2", "H", "H3"},
+		{"H9", "H", "H10"},
+		{"H2O", "H", "H2OH"},
+		{"C", "Cl", "CCl"},
+		{"Cl", "C", "ClC"},
+		{"Ca(", "O", "Ca(O"},
+		{"Ca(OH)", "H", "Ca(OH)H"},
+	}
+	for _, tt := range tests {
+		g, err := grid.CreateModel([]grid.Cell{element.CreateElement(element.Data{Symbol: tt.symbol}, false)}, grid.GridSettings{Rows: 1, Columns: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := model{grid: g, compound: tt.compound}
+		m.addToCompound()
+		if m.compound != tt.want {
+			t.Errorf("addToCompound(%q, %s) = %q, want %q", tt.compound, tt.symbol, m.compound, tt.want)
+		}
+	}
+}
+
+func TestCountCompound(t *testing.T) {
+	tests := []struct {
+		compound string
+		digit    string
+		want     string
+		counted  bool
+	}{
+		{"", "2", "", false},
+		{"H", "2", "H2", true},
+		{"H1", "2", "H12", true},
+		{"H", "0", "H", true},
+		{"H1", "0", "H10", true},
+		{"Ca(", "2", "Ca(", true},
+		{"Ca(OH)", "2", "Ca(OH)2", true},
+		{"Ca(OH)", "0", "Ca(OH)", true},
+	}
+	for _, tt := range tests {
+		m := model{compound: tt.compound}
+		counted := m.countCompound(tt.digit)
+		if m.compound != tt.want || counted != tt.counted {
+			t.Errorf("countCompound(%q, %s) = %q, %v, want %q, %v", tt.compound, tt.digit, m.compound, counted, tt.want, tt.counted)
+		}
+	}
+}
+
+func TestCloseGroup(t *testing.T) {
+	tests := []struct {
+		compound string
+		want     string
+	}{
+		{"Ca(OH", "Ca(OH)"},
+		{"Ca(OH)", "Ca(OH)"},
+		{"Ca(", "Ca("},
+		{"CaOH", "CaOH"},
+		{"K4(Fe(CN", "K4(Fe(CN)"},
+	}
+	for _, tt := range tests {
+		m := model{compound: tt.compound}
+		m.closeGroup()
+		if m.compound != tt.want {
+			t.Errorf("closeGroup(%q) = %q, want %q", tt.compound, m.compound, tt.want)
+		}
+	}
+}
+
+func TestDeleteFromCompound(t *testing.T) {
+	tests := []struct {
+		compound string
+		want     string
+	}{
+		{"", ""},
+		{"NaCl", "Na"},
+		{"Na", ""},
+		{"H2", "H"},
+		{"H12", "H1"},
+		{"Ca(OH)", "Ca(OH"},
+		{"Ca(", "Ca"},
+	}
+	for _, tt := range tests {
+		m := model{compound: tt.compound}
+		m.deleteFromCompound()
+		if m.compound != tt.want {
+			t.Errorf("deleteFromCompound(%q) = %q, want %q", tt.compound, m.compound, tt.want)
+		}
+	}
+}
+
+func TestClosedCompound(t *testing.T) {
+	tests := []struct {
+		compound string
+		want     string
+	}{
+		{"H2O", "H2O"},
+		{"Ca(OH", "Ca(OH)"},
+		{"Ca(OH)2(", "Ca(OH)2"},
+		{"K4(Fe(CN", "K4(Fe(CN))"},
+		{"K4(Fe(C(", "K4(Fe(C))"},
+		{"Ca((", "Ca"},
+	}
+	for _, tt := range tests {
+		if got := (model{compound: tt.compound}).closedCompound(); got != tt.want {
+			t.Errorf("closedCompound(%q) = %q, want %q", tt.compound, got, tt.want)
+		}
+	}
+}
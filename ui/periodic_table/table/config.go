@@ -0,0 +1,40 @@
+package table
+
+import (
+	"periodic-table/src/config"
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/heatmap"
+	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/scheme"
+)
+
+// configure picks up the key map and applies the color settings. The colors
+// are only changed when their settings changed, so a reload doesn't undo a
+// scheme chosen with the keys.
+func (m *model) configure(c config.Config) {
+	m.keys = keys.CreateKeys()
+	m.grid.SetKeyMap(gridKeys(m.keys))
+
+	if c.Scheme != m.config.Scheme || c.Heatmap != m.config.Heatmap || c.HeatmapScale != m.config.HeatmapScale {
+		if i, ok := scheme.Find(c.Scheme); ok {
+			m.schemeIndex = i
+		}
+		m.heatmapProperty = 0
+		for i, p := range heatmap.Properties {
+			if p == c.Heatmap {
+				m.heatmapProperty = i + 1
+			}
+		}
+		m.heatmapScale = heatmap.Linear
+		if c.HeatmapScale == "log" {
+			m.heatmapScale = heatmap.Log
+		}
+		m.clearCategory()
+	}
+	m.config = c
+	m.recolor()
+}
+
+func gridKeys(k keys.KeyMap) grid.KeyMap {
+	return grid.KeyMap{Up: k.Up, Down: k.Down, Left: k.Left, Right: k.Right}
+}
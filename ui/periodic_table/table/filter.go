@@ -0,0 +1,106 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"periodic-table/src/query"
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const filterPrompt = "Filter: "
+
+func (m *model) openFilter() {
+	m.state = filterMode
+	m.preview = m.filterQuery
+	m.filterErr = nil
+	if m.filterQuery != nil {
+		m.filter.SetValue(m.filterQuery.String())
+		m.filter.CursorEnd()
+	}
+	m.filter.Focus()
+}
+
+func (m *model) closeFilter() {
+	m.state = gridMode
+	m.filter.Reset()
+	m.filter.Blur()
+}
+
+// updateFilter edits the filter expression, previewing it on the grid as long
+// as it parses. Enter keeps the previewed filter and esc restores the previous
+// one.
+func (m *model) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.applyFilter(m.filterQuery)
+		m.closeFilter()
+	case key.Matches(msg, m.keys.Confirm):
+		if m.filterErr == nil {
+			m.filterQuery = m.preview
+			m.closeFilter()
+		}
+	default:
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		m.preview, m.filterErr = parseFilter(m.filter.Value())
+		if m.filterErr == nil {
+			m.applyFilter(m.preview)
+		}
+		return cmd
+	}
+	return nil
+}
+
+func parseFilter(source string) (*query.Query, error) {
+	if strings.TrimSpace(source) == "" {
+		return nil, nil
+	}
+	return query.Parse(source)
+}
+
+// applyFilter dims every element which doesn't match q or the category chosen
+// in the legend. A nil query only leaves the category.
+func (m *model) applyFilter(q *query.Query) {
+	inCategory := m.inCategory()
+	if q == nil && inCategory == nil {
+		m.grid.ClearFilter()
+		m.filterMatches = 0
+		return
+	}
+
+	m.filterMatches = m.grid.FilterCells(func(c grid.Cell) bool {
+		d, ok := c.GetData().(element.Data)
+		return ok && (q == nil || q.Match(d)) && (inCategory == nil || inCategory(d))
+	})
+}
+
+func (m model) filterBarView() string {
+	bar := m.filter.View()
+	if m.filterErr == nil {
+		if m.preview != nil {
+			bar = lipgloss.JoinHorizontal(0, bar, fmt.Sprintf("  %d matches", m.filterMatches))
+		}
+		return bar
+	}
+
+	var queryErr *query.Error
+	if errors.As(m.filterErr, &queryErr) {
+		marker := strings.Repeat(" ", lipgloss.Width(filterPrompt)+queryErr.Pos) + "^ "
+		return lipgloss.JoinVertical(0, bar, errorStyle().Render(marker+queryErr.Msg))
+	}
+	return lipgloss.JoinVertical(0, bar, errorStyle().Render(m.filterErr.Error()))
+}
+
+// filterStatusView describes the active filter while browsing the grid.
+func (m model) filterStatusView() string {
+	if m.filterQuery == nil {
+		return ""
+	}
+	return fmt.Sprintf("Filter: %s (%d matches)", m.filterQuery, m.filterMatches)
+}
@@ -0,0 +1,45 @@
+package table
+
+import (
+	"math"
+	"periodic-table/ui/periodic_table/theme"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpView renders every binding of the table in a box over the middle of
+// the screen. It is opened and closed with the help key.
+func (m model) helpView() string {
+	width := m.terminalWidth - helpOverlayStyle.GetHorizontalFrameSize()
+	body := lipgloss.JoinVertical(0,
+		helpTitleStyle.Render("Keys"),
+		"",
+		m.fullHelpView(width),
+		"",
+		legendHintStyle.Render("? or esc to close"),
+	)
+	return lipgloss.Place(m.terminalWidth, m.terminalHeight, lipgloss.Center, lipgloss.Center, helpOverlayStyle.Copy().BorderStyle(theme.Border(lipgloss.RoundedBorder())).Render(body))
+}
+
+// fullHelpView lays the help columns out in as many rows as are needed to fit
+// them in width.
+func (m model) fullHelpView(width int) string {
+	full := m.help
+	full.Width = math.MaxInt
+
+	var (
+		rows []string
+		row  [][]key.Binding
+	)
+	for _, column := range m.keys.FullHelp() {
+		candidate := append(append([][]key.Binding{}, row...), column)
+		if len(row) > 0 && lipgloss.Width(full.FullHelpView(candidate)) > width {
+			rows = append(rows, full.FullHelpView(row), "")
+			candidate = [][]key.Binding{column}
+		}
+		row = candidate
+	}
+	rows = append(rows, full.FullHelpView(row))
+	return lipgloss.JoinVertical(0, rows...)
+}
@@ -0,0 +1,110 @@
+package table
+
+import (
+	"fmt"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/scheme"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// activeCategorical returns the active scheme if it has categories the legend
+// can choose from.
+func (m model) activeCategorical() (scheme.Categorical, bool) {
+	c, ok := m.activeScheme().(scheme.Categorical)
+	return c, ok
+}
+
+func (m *model) focusLegend() {
+	c, ok := m.activeCategorical()
+	if !ok {
+		return
+	}
+
+	m.state = legendMode
+	m.legendCursor = 0
+	for i, category := range c.Categories() {
+		if category.Value == m.legendCategory {
+			m.legendCursor = i
+		}
+	}
+}
+
+// updateLegend moves through the categories of the legend. Choosing a
+// category highlights its elements, choosing it again clears the choice.
+func (m *model) updateLegend(msg tea.KeyMsg) {
+	c, ok := m.activeCategorical()
+	if !ok {
+		m.state = gridMode
+		return
+	}
+	categories := c.Categories()
+
+	switch {
+	case key.Matches(msg, m.keys.Left), key.Matches(msg, m.keys.Up):
+		m.legendCursor = (m.legendCursor - 1 + len(categories)) % len(categories)
+	case key.Matches(msg, m.keys.Right), key.Matches(msg, m.keys.Down):
+		m.legendCursor = (m.legendCursor + 1) % len(categories)
+	case key.Matches(msg, m.keys.ChooseCategory):
+		value := categories[m.legendCursor].Value
+		if m.legendCategory == value {
+			m.legendCategory = ""
+		} else {
+			m.legendCategory = value
+		}
+		m.applyFilter(m.filterQuery)
+	case key.Matches(msg, m.keys.Legend, m.keys.Cancel):
+		m.state = gridMode
+	}
+}
+
+// clearCategory forgets the chosen category, for example when the scheme it
+// belongs to is no longer shown.
+func (m *model) clearCategory() {
+	if m.legendCategory != "" {
+		m.legendCategory = ""
+		m.applyFilter(m.filterQuery)
+	}
+}
+
+// inCategory returns a matcher for the chosen legend category, or nil if no
+// category is chosen.
+func (m model) inCategory() func(d element.Data) bool {
+	c, ok := m.activeCategorical()
+	if !ok || m.legendCategory == "" {
+		return nil
+	}
+	return func(d element.Data) bool {
+		return strings.EqualFold(c.Value(d), m.legendCategory)
+	}
+}
+
+func (m model) legendView() string {
+	s := m.activeScheme()
+	c, ok := s.(scheme.Categorical)
+	if !ok {
+		return s.Legend(legendWidth)
+	}
+
+	counts := c.Counts(m.elementData())
+	var items []string
+	for i, category := range c.Categories() {
+		item := fmt.Sprintf("%s %s (%d)", category.Key(), category.Label, counts[category.Value])
+		switch {
+		case m.state == legendMode && i == m.legendCursor:
+			item = legendCursorStyle.Render(item)
+		case category.Value == m.legendCategory:
+			item = legendChosenStyle.Render(item)
+		}
+		items = append(items, item)
+	}
+
+	title := c.Name()
+	if m.state == legendMode {
+		title += legendHintStyle.Render("  ←/→ choose • enter highlight • tab/esc back")
+	}
+	return lipgloss.JoinVertical(0, title, scheme.Wrap(items, legendWidth))
+}
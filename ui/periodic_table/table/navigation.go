@@ -0,0 +1,114 @@
+package table
+
+import (
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/navigation"
+	"periodic-table/ui/periodic_table/views"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// navigate handles the grid mode keys beyond plain movement. Digits are
+// collected into a count which is consumed by the next key, as in "26G", or
+// count the last part of the compound being built.
+func (m *model) navigate(msg tea.KeyMsg) tea.Cmd {
+	if s := msg.String(); len(s) == 1 && s[0] >= '0' && s[0] <= '9' {
+		if m.countCompound(s) {
+			return nil
+		}
+		m.count += s
+		return nil
+	}
+
+	count := m.count
+	m.count = ""
+
+	if property, reverse, ok := navigation.Related(m.keys, msg); ok {
+		navigation.SelectRelated(&m.grid, property, reverse)
+		return nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.GoTo):
+		m.goTo(count)
+	case key.Matches(msg, m.keys.NextMatch):
+		m.grid.NextMatch()
+	case key.Matches(msg, m.keys.PrevMatch):
+		m.grid.PrevMatch()
+	case key.Matches(msg, m.keys.NextScheme):
+		m.cycleScheme(1)
+	case key.Matches(msg, m.keys.PrevScheme):
+		m.cycleScheme(-1)
+	case key.Matches(msg, m.keys.Heatmap):
+		m.cycleHeatmap()
+	case key.Matches(msg, m.keys.HeatmapScale):
+		m.toggleHeatmapScale()
+	case key.Matches(msg, m.keys.Legend):
+		m.focusLegend()
+	case key.Matches(msg, m.keys.Pin):
+		m.togglePin()
+	case key.Matches(msg, m.keys.ClearPins):
+		m.clearPins()
+	case key.Matches(msg, m.keys.Compare):
+		return views.Open(views.OpenCompareMsg{Elements: m.comparedElements()})
+	case key.Matches(msg, m.keys.AddToCompound):
+		m.addToCompound()
+	case key.Matches(msg, m.keys.OpenGroup):
+		m.openGroup()
+	case key.Matches(msg, m.keys.CloseGroup):
+		m.closeGroup()
+	case key.Matches(msg, m.keys.DeleteFromCompound):
+		m.deleteFromCompound()
+	case key.Matches(msg, m.keys.Calculator):
+		active, _ := m.activeElement()
+		var compound string
+		if m.compound != "" {
+			compound = m.closedCompound()
+		}
+		return views.Open(views.OpenCalculatorMsg{Element: active, Formula: compound})
+	case key.Matches(msg, m.keys.Details):
+		if active, ok := m.activeElement(); ok {
+			return views.Open(views.OpenElementMsg{Element: active})
+		}
+	case key.Matches(msg, m.keys.List):
+		active, _ := m.activeElement()
+		return views.Open(views.OpenListMsg{Element: active})
+	}
+	return nil
+}
+
+// selectElement selects the cell showing d.
+func (m *model) selectElement(d element.Data) {
+	if d.AtomicNumber != "" {
+		m.goTo(d.AtomicNumber)
+	}
+}
+
+// goTo selects the element with the given atomic number, or the last element
+// if no number was typed.
+func (m *model) goTo(count string) {
+	number, err := strconv.Atoi(count)
+	if err != nil {
+		number = m.lastAtomicNumber
+	}
+	navigation.GoTo(&m.grid, number)
+}
+
+func (m model) activeElement() (element.Data, bool) {
+	return navigation.ActiveElement(&m.grid)
+}
+
+func lastAtomicNumber(cells []grid.Cell) int {
+	var last int
+	for _, c := range cells {
+		if d, ok := c.GetData().(element.Data); ok {
+			if number, err := strconv.Atoi(d.AtomicNumber); err == nil && number > last {
+				last = number
+			}
+		}
+	}
+	return last
+}
@@ -0,0 +1,57 @@
+package table
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"strings"
+)
+
+// togglePin adds the active element to the compare tray, or removes it if it
+// is already there.
+func (m *model) togglePin() {
+	active, ok := m.activeElement()
+	if !ok {
+		return
+	}
+
+	for i, d := range m.pins {
+		if d.AtomicNumber == active.AtomicNumber {
+			m.pins = append(m.pins[:i:i], m.pins[i+1:]...)
+			(*m.grid.GetActiveCell()).SetMarked(false)
+			return
+		}
+	}
+
+	m.pins = append(m.pins, active)
+	(*m.grid.GetActiveCell()).SetMarked(true)
+}
+
+func (m *model) clearPins() {
+	m.pins = nil
+	for _, c := range m.grid.GetCells() {
+		c.SetMarked(false)
+	}
+}
+
+// comparedElements returns the pinned elements, or the active one if nothing
+// is pinned.
+func (m model) comparedElements() []element.Data {
+	if len(m.pins) > 0 {
+		return m.pins
+	}
+	if active, ok := m.activeElement(); ok {
+		return []element.Data{active}
+	}
+	return nil
+}
+
+func (m model) trayView() string {
+	if len(m.pins) == 0 {
+		return ""
+	}
+
+	var symbols []string
+	for _, d := range m.pins {
+		symbols = append(symbols, d.Symbol)
+	}
+	return "Pinned: " + strings.Join(symbols, ", ")
+}
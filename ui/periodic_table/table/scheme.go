@@ -0,0 +1,62 @@
+package table
+
+import (
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/heatmap"
+	"periodic-table/ui/periodic_table/scheme"
+)
+
+const legendWidth = 100
+
+// cycleScheme switches to the next categorical scheme, leaving heatmap mode.
+func (m *model) cycleScheme(step int) {
+	if m.heatmapProperty == 0 {
+		m.schemeIndex = (m.schemeIndex + step + len(scheme.Schemes)) % len(scheme.Schemes)
+	}
+	m.heatmapProperty = 0
+	m.clearCategory()
+	m.recolor()
+}
+
+// cycleHeatmap switches to the next heatmap property, returning to the
+// categorical scheme after the last one.
+func (m *model) cycleHeatmap() {
+	m.heatmapProperty = (m.heatmapProperty + 1) % (len(heatmap.Properties) + 1)
+	m.clearCategory()
+	m.recolor()
+}
+
+func (m *model) toggleHeatmapScale() {
+	if m.heatmapScale == heatmap.Linear {
+		m.heatmapScale = heatmap.Log
+	} else {
+		m.heatmapScale = heatmap.Linear
+	}
+	m.recolor()
+}
+
+// activeScheme returns the scheme the grid is colored by.
+func (m model) activeScheme() scheme.Scheme {
+	if m.heatmapProperty > 0 {
+		field, ok := element.FieldByName(heatmap.Properties[m.heatmapProperty-1])
+		if ok {
+			return heatmap.New(field, m.heatmapScale, m.elementData())
+		}
+	}
+	return scheme.Schemes[m.schemeIndex]
+}
+
+// recolor recomputes the style of every element from the active scheme.
+func (m *model) recolor() {
+	scheme.Apply(m.activeScheme(), m.grid.GetCells())
+}
+
+func (m model) elementData() []element.Data {
+	var data []element.Data
+	for _, c := range m.grid.GetCells() {
+		if d, ok := c.GetData().(element.Data); ok && !c.IsPaddingCell() {
+			data = append(data, d)
+		}
+	}
+	return data
+}
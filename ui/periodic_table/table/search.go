@@ -0,0 +1,47 @@
+package table
+
+import (
+	"fmt"
+	"periodic-table/ui/periodic_table/element"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const maxSearchResults = 5
+
+func (m model) searchBarView() string {
+	bar := m.search.View()
+	if matches := len(m.grid.GetMatches()); matches > 0 {
+		bar = lipgloss.JoinHorizontal(0, bar, fmt.Sprintf("  %d/%d", m.grid.GetActiveMatch()+1, matches))
+	}
+	return bar
+}
+
+// searchResultsView lists the candidates of the current search below the
+// search bar, keeping the active result in view.
+func (m model) searchResultsView() string {
+	matches := m.grid.GetMatches()
+	active := m.grid.GetActiveMatch()
+
+	start := 0
+	if active >= maxSearchResults {
+		start = active - maxSearchResults + 1
+	}
+
+	var lines []string
+	for i := start; i < len(matches) && i < start+maxSearchResults; i++ {
+		d, ok := matches[i].GetData().(element.Data)
+		if !ok {
+			continue
+		}
+
+		line := fmt.Sprintf("%-3s %3s  %s", d.Symbol, d.AtomicNumber, d.Element)
+		if i == active {
+			lines = append(lines, activeSearchResultStyle.Render("> "+line))
+		} else {
+			lines = append(lines, searchResultStyle.Render("  "+line))
+		}
+	}
+
+	return lipgloss.JoinVertical(0, lines...)
+}
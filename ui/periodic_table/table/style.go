@@ -0,0 +1,21 @@
+package table
+
+import (
+	"periodic-table/ui/periodic_table/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	searchResultStyle       = lipgloss.NewStyle().Faint(true)
+	activeSearchResultStyle = lipgloss.NewStyle().Bold(true)
+	legendCursorStyle       = lipgloss.NewStyle().Reverse(true)
+	legendChosenStyle       = lipgloss.NewStyle().Bold(true).Underline(true)
+	legendHintStyle         = lipgloss.NewStyle().Faint(true)
+	helpOverlayStyle        = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(1, 2)
+	helpTitleStyle          = lipgloss.NewStyle().Bold(true)
+)
+
+func errorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Current().Error)
+}
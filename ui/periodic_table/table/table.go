@@ -2,18 +2,25 @@ package table
 
 import (
 	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"periodic-table/src/config"
+	"periodic-table/src/query"
 	"periodic-table/ui/grid"
 	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/heatmap"
 	"periodic-table/ui/periodic_table/keys"
+	"periodic-table/ui/periodic_table/views"
 )
 
 const (
 	gridMode = iota
 	searchMode
+	filterMode
+	legendMode
 )
 
 const bottomBarHeight = 1
@@ -25,7 +32,33 @@ type model struct {
 	help           help.Model
 	keys           keys.KeyMap
 	search         textinput.Model
+	terminalWidth  int
 	terminalHeight int
+
+	count            string
+	lastAtomicNumber int
+
+	filter        textinput.Model
+	filterQuery   *query.Query
+	preview       *query.Query
+	filterErr     error
+	filterMatches int
+
+	schemeIndex     int
+	heatmapProperty int
+	heatmapScale    heatmap.Scale
+
+	pins []element.Data
+
+	// compound is the formula built from the table, such as "Ca(OH)2",
+	// empty when none is being built.
+	compound string
+	data     []element.Data
+
+	legendCursor   int
+	legendCategory string
+
+	config config.Config
 }
 
 func (m model) Init() tea.Cmd {
@@ -37,39 +70,77 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmd  tea.Cmd
 		cmds []tea.Cmd
 	)
-	m.grid, cmd = m.grid.Update(msg)
-	cmds = append(cmds, cmd)
+	if m.state == gridMode {
+		m.grid, cmd = m.grid.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 
 	switch msg := msg.(type) {
+	case views.OpenTableMsg:
+		m.selectElement(msg.Element)
+	case views.ConfigMsg:
+		m.configure(msg.Config)
 	case tea.WindowSizeMsg:
 		maxHeight := lipgloss.Height(m.grid.View())
-		m.terminalHeight = msg.Height
+		m.terminalWidth, m.terminalHeight = msg.Width, msg.Height
+		m.help.Width = msg.Width
 		if msg.Height > maxHeight {
 			m.viewport = viewport.New(msg.Width, maxHeight)
 		} else {
 			m.viewport = viewport.New(msg.Width, m.terminalHeight-1)
 		}
 	case tea.KeyMsg:
-		key := msg.String()
+		if m.help.ShowAll {
+			if key.Matches(msg, m.keys.Help, m.keys.Cancel) {
+				m.help.ShowAll = false
+			} else if key.Matches(msg, m.keys.Quit) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Quit) && (msg.Type != tea.KeyRunes || !m.typing()) {
+			return m, tea.Quit
+		}
 		if m.state == gridMode {
-			switch key {
-			case "/":
+			switch {
+			case key.Matches(msg, m.keys.Search):
 				m.state = searchMode
 				m.search.Focus()
-			case "q":
-				return m, tea.Quit
+			case key.Matches(msg, m.keys.Filter):
+				m.openFilter()
+			case key.Matches(msg, m.keys.Help):
+				m.help.ShowAll = true
+			case key.Matches(msg, m.keys.Cancel):
+				m.count = ""
+				m.compound = ""
+				m.grid.ClearSearch()
+			default:
+				cmds = append(cmds, m.navigate(msg))
 			}
 		} else if m.state == searchMode {
-			if key == "esc" || key == "enter" {
+			switch {
+			case key.Matches(msg, m.keys.Cancel):
+				m.grid.ClearSearch()
+				fallthrough
+			case key.Matches(msg, m.keys.Confirm):
 				m.state = gridMode
 				m.search.Reset()
-			} else {
+			case key.Matches(msg, m.keys.NextResult):
+				m.grid.NextMatch()
+			case key.Matches(msg, m.keys.PrevResult):
+				m.grid.PrevMatch()
+			default:
 				m.search, cmd = m.search.Update(msg)
-				if value := m.search.Value(); value != "" {
-					m.grid.SearchCells(value)
-				}
+				m.grid.SearchCells(m.search.Value())
+				m.viewport.SetContent(m.grid.View())
 				return m, cmd
 			}
+		} else if m.state == legendMode {
+			m.updateLegend(msg)
+		} else if m.state == filterMode {
+			cmd = m.updateFilter(msg)
+			m.viewport.SetContent(m.grid.View())
+			return m, cmd
 		}
 	}
 	m.viewport.SetContent(m.grid.View())
@@ -79,22 +150,48 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) View() string {
+	if m.help.ShowAll {
+		return m.helpView()
+	}
 	text := lipgloss.JoinHorizontal(0, m.viewport.View(), m.getElementInfoView())
 	relativeBottomBarPos := m.terminalHeight - lipgloss.Height(m.grid.View())
 	if m.state == searchMode {
-		searchBar := lipgloss.PlaceVertical(relativeBottomBarPos, lipgloss.Bottom, m.search.View())
+		searchBar := lipgloss.JoinVertical(0, m.searchBarView(), m.searchResultsView())
+		searchBar = lipgloss.PlaceVertical(relativeBottomBarPos, lipgloss.Bottom, searchBar)
 		text = lipgloss.JoinVertical(0, text, searchBar)
-	} else if m.state == gridMode {
-		helpBar := lipgloss.PlaceVertical(relativeBottomBarPos, lipgloss.Bottom, m.help.View(m.keys))
+	} else if m.state == filterMode {
+		filterBar := lipgloss.PlaceVertical(relativeBottomBarPos, lipgloss.Bottom, m.filterBarView())
+		text = lipgloss.JoinVertical(0, text, filterBar)
+	} else if m.state == gridMode || m.state == legendMode {
+		bar := m.help.View(m.keys)
+		if m.count != "" {
+			bar = "Go to: " + m.count
+		}
+		if status := m.filterStatusView(); status != "" {
+			bar = lipgloss.JoinVertical(0, status, bar)
+		}
+		if tray := m.trayView(); tray != "" {
+			bar = lipgloss.JoinVertical(0, tray, bar)
+		}
+		if compound := m.compoundView(); compound != "" {
+			bar = lipgloss.JoinVertical(0, compound, bar)
+		}
+		bar = lipgloss.JoinVertical(0, m.legendView(), bar)
+		helpBar := lipgloss.PlaceVertical(relativeBottomBarPos, lipgloss.Bottom, bar)
 		text = lipgloss.JoinVertical(0, text, helpBar)
 	}
 
 	return text
 }
 
+// typing reports whether keys are going to a text input, in which case
+// printable keys are text rather than commands.
+func (m model) typing() bool {
+	return m.state == searchMode || m.state == filterMode
+}
+
 func (m model) getElementInfoView() string {
-	switch elementData := (*m.grid.GetActiveCell()).GetData().(type) {
-	case element.Data:
+	if elementData, ok := m.activeElement(); ok {
 		return element.ElementInfoView(elementData)
 	}
 	return ""
@@ -104,16 +201,32 @@ func CreateModel(cells []grid.Cell) (tea.Model, error) {
 	search := textinput.New()
 	search.Prompt = "Search: "
 
+	filter := textinput.New()
+	filter.Prompt = filterPrompt
+
 	g, err := grid.CreateModel(cells, grid.GridSettings{Rows: 10, Columns: 18})
 	if err != nil {
 		return nil, err
 	}
+	keyMap := keys.CreateKeys()
+	g.SetKeyMap(gridKeys(keyMap))
+
+	var data []element.Data
+	for _, c := range cells {
+		if d, ok := c.GetData().(element.Data); ok {
+			data = append(data, d)
+		}
+	}
 
 	model := model{
 		help:   help.New(),
 		search: search,
-		keys:   keys.CreateKeys(),
+		filter: filter,
+		keys:   keyMap,
 		grid:   g,
+		data:   data,
+
+		lastAtomicNumber: lastAtomicNumber(cells),
 	}
 	return model, nil
 }
@@ -0,0 +1,134 @@
+package theme
+
+import "github.com/charmbracelet/lipgloss"
+
+// The base colors of the schemes are tuned for dark terminals. On light
+// terminals they are darkened so they stand out from the background.
+var (
+	darkBase  = adjustment{}
+	lightBase = adjustment{minLightness: 0, maxLightness: 0.55}
+)
+
+var (
+	noData     = lipgloss.AdaptiveColor{Light: "#9e9e9e", Dark: "#626262"}
+	dimmed     = lipgloss.AdaptiveColor{Light: "#d0d0d0", Dark: "#3a3a3a"}
+	dimmedText = lipgloss.AdaptiveColor{Light: "#a8a8a8", Dark: "#5c5c5c"}
+	errorColor = lipgloss.AdaptiveColor{Light: "#d70000", Dark: "#ff5f5f"}
+	highest    = lipgloss.AdaptiveColor{Light: "#008700", Dark: "#5fd75f"}
+	lowest     = lipgloss.AdaptiveColor{Light: "#d70000", Dark: "#ff8787"}
+)
+
+// only uses the light or the dark variant of c on every terminal.
+func only(c lipgloss.AdaptiveColor, dark bool) lipgloss.AdaptiveColor {
+	if dark {
+		return lipgloss.AdaptiveColor{Light: c.Dark, Dark: c.Dark}
+	}
+	return lipgloss.AdaptiveColor{Light: c.Light, Dark: c.Light}
+}
+
+// Default adapts to the background of the terminal.
+var Default = Theme{
+	Name:       "default",
+	light:      lightBase,
+	dark:       darkBase,
+	NoData:     noData,
+	Dimmed:     dimmed,
+	DimmedText: dimmedText,
+	Error:      errorColor,
+	Highest:    highest,
+	Lowest:     lowest,
+}
+
+// fixed returns Default with the colors for a dark or a light background
+// whatever the terminal reports.
+func fixed(name string, dark bool) Theme {
+	base := lightBase
+	if dark {
+		base = darkBase
+	}
+	return Theme{
+		Name:       name,
+		light:      base,
+		dark:       base,
+		NoData:     only(noData, dark),
+		Dimmed:     only(dimmed, dark),
+		DimmedText: only(dimmedText, dark),
+		Error:      only(errorColor, dark),
+		Highest:    only(highest, dark),
+		Lowest:     only(lowest, dark),
+	}
+}
+
+// HighContrast pushes every color far from the background and keeps dimmed
+// and missing elements readable.
+var HighContrast = Theme{
+	Name:       "high-contrast",
+	light:      adjustment{minLightness: 0, maxLightness: 0.4, minChroma: 0.5},
+	dark:       adjustment{minLightness: 0.75, maxLightness: 1, minChroma: 0.5},
+	NoData:     lipgloss.AdaptiveColor{Light: "#585858", Dark: "#a8a8a8"},
+	Dimmed:     lipgloss.AdaptiveColor{Light: "#8a8a8a", Dark: "#767676"},
+	DimmedText: lipgloss.AdaptiveColor{Light: "#585858", Dark: "#a8a8a8"},
+	Error:      lipgloss.AdaptiveColor{Light: "#af0000", Dark: "#ff5f5f"},
+	Highest:    lipgloss.AdaptiveColor{Light: "#005f00", Dark: "#87ff87"},
+	Lowest:     lipgloss.AdaptiveColor{Light: "#af0000", Dark: "#ffafaf"},
+}
+
+// The Okabe-Ito palette can be told apart with every common form of color
+// blindness. It has eight colors, so the element types reuse a color only for
+// categories which are far apart in the table.
+var (
+	orange        = same("#E69F00")
+	skyBlue       = same("#56B4E9")
+	bluishGreen   = same("#009E73")
+	yellow        = same("#F0E442")
+	blue          = same("#0072B2")
+	vermillion    = same("#D55E00")
+	reddishPurple = same("#CC79A7")
+	black         = lipgloss.AdaptiveColor{Light: "#000000", Dark: "#ffffff"}
+	grey          = same("#999999")
+)
+
+func same(hex string) lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Light: hex, Dark: hex}
+}
+
+var OkabeIto = Theme{
+	Name: "okabe-ito",
+	Categories: map[string]map[string]lipgloss.AdaptiveColor{
+		"type": {
+			"Alkali Metal":         vermillion,
+			"Alkaline Earth Metal": orange,
+			"Transition Metal":     reddishPurple,
+			"Metal":                skyBlue,
+			"Metalloid":            yellow,
+			"Nonmetal":             bluishGreen,
+			"Halogen":              blue,
+			"Noble Gas":            black,
+			"Lanthanide":           orange,
+			"Actinide":             blue,
+			"Transactinide":        grey,
+		},
+		"block":         {"s": vermillion, "p": orange, "d": blue, "f": bluishGreen},
+		"phase":         {"solid": skyBlue, "liquid": blue, "gas": orange, "artificial": reddishPurple},
+		"origin":        {"yes": bluishGreen, "no": reddishPurple},
+		"metallicity":   {"metal": blue, "metalloid": yellow, "nonmetal": vermillion},
+		"radioactivity": {"yes": orange, "no": skyBlue},
+	},
+	light:      lightBase,
+	dark:       darkBase,
+	NoData:     noData,
+	Dimmed:     dimmed,
+	DimmedText: dimmedText,
+	Error:      same("#D55E00"),
+	Highest:    same("#009E73"),
+	Lowest:     same("#D55E00"),
+}
+
+// Builtin are the themes which are always available, by name.
+var Builtin = map[string]Theme{
+	"default":       Default,
+	"dark":          fixed("dark", true),
+	"light":         fixed("light", false),
+	"high-contrast": HighContrast,
+	"okabe-ito":     OkabeIto,
+}
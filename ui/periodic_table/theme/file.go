@@ -0,0 +1,210 @@
+package theme
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// Error is returned for a theme file which can't be used. Line and Column
+// locate the offending value, counted from one.
+type Error struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+func newError(node *yaml.Node, format string, a ...interface{}) error {
+	return &Error{Line: node.Line, Column: node.Column, Msg: fmt.Sprintf(format, a...)}
+}
+
+// Names returns the names of the built in themes.
+func Names() []string {
+	var names []string
+	for name := range Builtin {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// uiColors are the colors a theme file can set besides the categories.
+func uiColors(t *Theme) map[string]*lipgloss.AdaptiveColor {
+	return map[string]*lipgloss.AdaptiveColor{
+		"no_data":     &t.NoData,
+		"dimmed":      &t.Dimmed,
+		"dimmed_text": &t.DimmedText,
+		"error":       &t.Error,
+		"highest":     &t.Highest,
+		"lowest":      &t.Lowest,
+	}
+}
+
+// Parse reads a custom theme. A theme file changes the colors of a built in
+// theme, "default" unless it names another with extends:
+//
+//	extends: okabe-ito
+//	categories:
+//	  type:
+//	    Halogen: "#0072B2"
+//	colors:
+//	  error: {light: "#af0000", dark: "#ff5f5f"}
+//
+// categories lists the values of each scheme which can be given a color.
+func Parse(name string, source []byte, categories map[string][]string) (Theme, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(source, &doc); err != nil {
+		return Theme{}, err
+	}
+
+	t := Default
+	if len(doc.Content) == 0 {
+		t.Name = name
+		return t, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return Theme{}, newError(root, "expected a mapping")
+	}
+
+	if extends := lookup(root, "extends"); extends != nil {
+		base, ok := Builtin[extends.Value]
+		if !ok {
+			return Theme{}, newError(extends, "unknown theme %q, expected one of %s", extends.Value, strings.Join(Names(), ", "))
+		}
+		t = base
+	}
+	t.Name = name
+	overrides := map[string]map[string]lipgloss.AdaptiveColor{}
+	for scheme, colors := range t.Categories {
+		overrides[scheme] = map[string]lipgloss.AdaptiveColor{}
+		for value, c := range colors {
+			overrides[scheme][value] = c
+		}
+	}
+	t.Categories = overrides
+
+	for i := 0; i < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		var err error
+		switch key.Value {
+		case "extends":
+		case "categories":
+			err = parseCategories(value, categories, t.Categories)
+		case "colors":
+			err = parseColors(value, uiColors(&t))
+		default:
+			err = newError(key, "unknown setting %q, expected one of extends, categories, colors", key.Value)
+		}
+		if err != nil {
+			return Theme{}, err
+		}
+	}
+	return t, nil
+}
+
+func parseCategories(node *yaml.Node, known map[string][]string, categories map[string]map[string]lipgloss.AdaptiveColor) error {
+	if node.Kind != yaml.MappingNode {
+		return newError(node, "categories must be a mapping of schemes")
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		scheme, values := node.Content[i], node.Content[i+1]
+		knownValues, ok := known[scheme.Value]
+		if !ok {
+			return newError(scheme, "unknown scheme %q", scheme.Value)
+		}
+		if values.Kind != yaml.MappingNode {
+			return newError(values, "categories of %s must be a mapping", scheme.Value)
+		}
+		if categories[scheme.Value] == nil {
+			categories[scheme.Value] = map[string]lipgloss.AdaptiveColor{}
+		}
+		for j := 0; j < len(values.Content); j += 2 {
+			value := values.Content[j]
+			if !slices.Contains(knownValues, value.Value) {
+				return newError(value, "unknown %s category %q, expected one of %s", scheme.Value, value.Value, strings.Join(knownValues, ", "))
+			}
+			c, err := parseColor(values.Content[j+1])
+			if err != nil {
+				return err
+			}
+			categories[scheme.Value][value.Value] = c
+		}
+	}
+	return nil
+}
+
+func parseColors(node *yaml.Node, colors map[string]*lipgloss.AdaptiveColor) error {
+	if node.Kind != yaml.MappingNode {
+		return newError(node, "colors must be a mapping")
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+		target, ok := colors[key.Value]
+		if !ok {
+			var names []string
+			for name := range colors {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return newError(key, "unknown color %q, expected one of %s", key.Value, strings.Join(names, ", "))
+		}
+		c, err := parseColor(node.Content[i+1])
+		if err != nil {
+			return err
+		}
+		*target = c
+	}
+	return nil
+}
+
+// parseColor reads either a single hex color or a mapping with a color for
+// light and for dark terminals.
+func parseColor(node *yaml.Node) (lipgloss.AdaptiveColor, error) {
+	if node.Kind == yaml.ScalarNode {
+		if err := checkHex(node); err != nil {
+			return lipgloss.AdaptiveColor{}, err
+		}
+		return same(node.Value), nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return lipgloss.AdaptiveColor{}, newError(node, `expected a color such as "#4b93c6" or {light: ..., dark: ...}`)
+	}
+
+	light, dark := lookup(node, "light"), lookup(node, "dark")
+	if light == nil || dark == nil || len(node.Content) != 4 {
+		return lipgloss.AdaptiveColor{}, newError(node, "an adaptive color needs exactly a light and a dark color")
+	}
+	for _, n := range []*yaml.Node{light, dark} {
+		if err := checkHex(n); err != nil {
+			return lipgloss.AdaptiveColor{}, err
+		}
+	}
+	return lipgloss.AdaptiveColor{Light: light.Value, Dark: dark.Value}, nil
+}
+
+func checkHex(node *yaml.Node) error {
+	if _, err := colorful.Hex(node.Value); node.Kind != yaml.ScalarNode || err != nil {
+		return newError(node, "%q is not a hex color such as \"#4b93c6\"", node.Value)
+	}
+	return nil
+}
+
+// lookup returns the value of the named key of a mapping node.
+func lookup(node *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package theme
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Profile decides what the table is drawn with, for terminals which can't
+// show colors or box-drawing characters.
+type Profile int
+
+const (
+	// Auto draws Plain when NO_COLOR is set or the terminal has no colors,
+	// and Color otherwise.
+	Auto Profile = iota
+	// Color draws cells with colored box-drawing borders.
+	Color
+	// Plain draws without color using ASCII characters only. Categories are
+	// shown as letter codes in each cell and the selection in brackets.
+	Plain
+)
+
+// Profiles are the profiles by their name in settings.
+var Profiles = map[string]Profile{
+	"auto":  Auto,
+	"color": Color,
+	"plain": Plain,
+}
+
+// ASCIIBorder draws boxes without box-drawing characters.
+var ASCIIBorder = lipgloss.Border{
+	Top:         "-",
+	Bottom:      "-",
+	Left:        "|",
+	Right:       "|",
+	TopLeft:     "+",
+	TopRight:    "+",
+	BottomLeft:  "+",
+	BottomRight: "+",
+}
+
+var (
+	profile = Color
+	// detected is the color profile of the terminal, which lipgloss reads
+	// from the environment honoring NO_COLOR.
+	detected = lipgloss.ColorProfile()
+)
+
+// IsPlain reports whether the Plain profile is in use.
+func IsPlain() bool {
+	return profile == Plain
+}
+
+// SetProfile changes the profile in use, resolving Auto from the terminal.
+func SetProfile(p Profile) {
+	if p == Auto {
+		p = Color
+		if detected == termenv.Ascii {
+			p = Plain
+		}
+	}
+	profile = p
+
+	if p == Plain {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	} else {
+		lipgloss.SetColorProfile(detected)
+	}
+}
+
+// ForceColor uses the Color profile even if the output is no terminal, such
+// as when printing to a pipe. Colors are drawn in true color unless the
+// terminal's own colors were detected.
+func ForceColor() {
+	profile = Color
+	if detected == termenv.Ascii {
+		lipgloss.SetColorProfile(termenv.TrueColor)
+	} else {
+		lipgloss.SetColorProfile(detected)
+	}
+}
+
+// Border returns b, or ASCIIBorder in the Plain profile.
+func Border(b lipgloss.Border) lipgloss.Border {
+	if IsPlain() {
+		return ASCIIBorder
+	}
+	return b
+}
+
+// asciiReplacer stands in for the characters outside ASCII the views draw,
+// one character for one so that columns stay aligned.
+var asciiReplacer = strings.NewReplacer(
+	"↑", "^", "↓", "v", "←", "<", "→", ">", "▲", "^", "▼", "v",
+	"•", "*", "·", ".", "—", "-", "–", "-", "…", "~", "×", "x", "°", "o",
+	"─", "-", "│", "|", "╱", "/", "╲", "\\", "┊", ":", "╌", "-", "█", "#", "■", "#", "●", "o",
+	"Å", "A", "⁺", "+", "⁻", "-",
+	"⁰", "0", "¹", "1", "²", "2", "³", "3", "⁴", "4", "⁵", "5", "⁶", "6", "⁷", "7", "⁸", "8", "⁹", "9",
+	"₀", "0", "₁", "1", "₂", "2", "₃", "3", "₄", "4", "₅", "5", "₆", "6", "₇", "7", "₈", "8", "₉", "9",
+)
+
+// ASCII returns s with every character outside ASCII replaced by a look-alike,
+// or by "?" if there is none, in the Plain profile, for serial consoles which
+// garble anything else. It returns s unchanged in other profiles.
+func ASCII(s string) string {
+	if !IsPlain() {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII {
+			return '?'
+		}
+		return r
+	}, asciiReplacer.Replace(s))
+}
@@ -0,0 +1,117 @@
+// Package theme holds the colors the table is drawn in. Colors are lipgloss
+// adaptive colors, so a theme can look right on both light and dark
+// terminals.
+package theme
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/termenv"
+)
+
+// Theme decides the color of every category of every scheme and of the
+// parts of the interface which aren't colored by a scheme.
+type Theme struct {
+	Name string
+
+	// Categories overrides the colors of categories, keyed by the ID of a
+	// scheme and then by the value of a category.
+	Categories map[string]map[string]lipgloss.AdaptiveColor
+	// light and dark adjust the base color of a category, tuned for dark
+	// terminals, when it isn't overridden.
+	light, dark adjustment
+
+	NoData     lipgloss.AdaptiveColor
+	Dimmed     lipgloss.AdaptiveColor
+	DimmedText lipgloss.AdaptiveColor
+	Error      lipgloss.AdaptiveColor
+	Highest    lipgloss.AdaptiveColor
+	Lowest     lipgloss.AdaptiveColor
+}
+
+var current = Default
+
+// Current returns the theme in use.
+func Current() Theme {
+	return current
+}
+
+// Set changes the theme in use.
+func Set(t Theme) {
+	current = t
+}
+
+// Category returns the color of a category of a scheme, given the base color
+// the scheme defines for it.
+func (t Theme) Category(scheme, value string, base lipgloss.Color) lipgloss.TerminalColor {
+	if color, ok := t.Categories[scheme][value]; ok {
+		return color
+	}
+	return lipgloss.AdaptiveColor{Light: t.light.apply(string(base)), Dark: t.dark.apply(string(base))}
+}
+
+// TextOn returns black or white, whichever is easier to read on background.
+func TextOn(background lipgloss.TerminalColor) lipgloss.TerminalColor {
+	switch c := background.(type) {
+	case lipgloss.Color:
+		return lipgloss.Color(textOn(string(c)))
+	case lipgloss.AdaptiveColor:
+		return lipgloss.AdaptiveColor{Light: textOn(c.Light), Dark: textOn(c.Dark)}
+	}
+	return lipgloss.NoColor{}
+}
+
+// Hex returns c as a hex color for output other than the terminal, in its
+// variant for dark or light backgrounds. It returns false for colors which
+// aren't drawn, such as lipgloss.NoColor.
+func Hex(c lipgloss.TerminalColor, dark bool) (string, bool) {
+	switch c := c.(type) {
+	case lipgloss.Color:
+		if n, err := strconv.Atoi(string(c)); err == nil && n >= 0 && n < 256 {
+			return termenv.ANSI256Color(n).String(), true
+		}
+		if _, err := colorful.Hex(string(c)); err == nil {
+			return string(c), true
+		}
+	case lipgloss.AdaptiveColor:
+		if dark {
+			return Hex(lipgloss.Color(c.Dark), dark)
+		}
+		return Hex(lipgloss.Color(c.Light), dark)
+	}
+	return "", false
+}
+
+func textOn(hex string) string {
+	c, err := colorful.Hex(hex)
+	if err != nil {
+		return ""
+	}
+	// Relative luminance as defined by WCAG. Above 0.179 black text has the
+	// higher contrast ratio.
+	r, g, b := c.LinearRgb()
+	if 0.2126*r+0.7152*g+0.0722*b > 0.179 {
+		return "#000000"
+	}
+	return "#ffffff"
+}
+
+// adjustment limits the lightness and raises the chroma of a color in HCL
+// space, keeping its hue. The zero adjustment leaves colors unchanged.
+type adjustment struct {
+	minLightness, maxLightness float64
+	minChroma                  float64
+}
+
+func (a adjustment) apply(hex string) string {
+	c, err := colorful.Hex(hex)
+	if err != nil || a == (adjustment{}) {
+		return hex
+	}
+	h, chroma, l := c.Hcl()
+	l = math.Min(math.Max(l, a.minLightness), a.maxLightness)
+	return colorful.Hcl(h, math.Max(chroma, a.minChroma), l).Clamped().Hex()
+}
@@ -0,0 +1,171 @@
+package theme
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var categories = map[string][]string{
+	"type":  {"Halogen", "Nonmetal"},
+	"block": {"s", "p", "d", "f"},
+}
+
+func TestTextOn(t *testing.T) {
+	tests := []struct {
+		background lipgloss.TerminalColor
+		want       lipgloss.TerminalColor
+	}{
+		{lipgloss.Color("#ffffff"), lipgloss.Color("#000000")},
+		{lipgloss.Color("#052cbf"), lipgloss.Color("#ffffff")},
+		{lipgloss.Color("#e4a54d"), lipgloss.Color("#000000")},
+		{lipgloss.AdaptiveColor{Light: "#052cbf", Dark: "#F0E442"}, lipgloss.AdaptiveColor{Light: "#ffffff", Dark: "#000000"}},
+		{lipgloss.Color("12"), lipgloss.Color("")},
+	}
+	for _, tt := range tests {
+		if got := TextOn(tt.background); got != tt.want {
+			t.Errorf("TextOn(%v) = %v, want %v", tt.background, got, tt.want)
+		}
+	}
+}
+
+func TestHex(t *testing.T) {
+	tests := []struct {
+		color lipgloss.TerminalColor
+		dark  bool
+		want  string
+		ok    bool
+	}{
+		{lipgloss.Color("#052cbf"), true, "#052cbf", true},
+		{lipgloss.AdaptiveColor{Light: "#052cbf", Dark: "#F0E442"}, true, "#F0E442", true},
+		{lipgloss.AdaptiveColor{Light: "#052cbf", Dark: "#F0E442"}, false, "#052cbf", true},
+		{lipgloss.Color("9"), true, "#ff0000", true},
+		{lipgloss.NoColor{}, true, "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := Hex(tt.color, tt.dark); got != tt.want || ok != tt.ok {
+			t.Errorf("Hex(%v, %v) = %q, %v, want %q, %v", tt.color, tt.dark, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestTheme_Category(t *testing.T) {
+	base := lipgloss.Color("#e4a54d")
+
+	dark := Builtin["dark"].Category("type", "Metalloid", base)
+	if want := (lipgloss.AdaptiveColor{Light: "#e4a54d", Dark: "#e4a54d"}); dark != want {
+		t.Errorf("dark theme color = %v, want %v", dark, want)
+	}
+	light := Builtin["light"].Category("type", "Metalloid", base).(lipgloss.AdaptiveColor)
+	if light.Light == string(base) || light.Light != light.Dark {
+		t.Errorf("light theme color = %v, want a darker color on every terminal", light)
+	}
+	if got := OkabeIto.Category("type", "Halogen", base); got != blue {
+		t.Errorf("okabe-ito Halogen = %v, want %v", got, blue)
+	}
+}
+
+func TestParse(t *testing.T) {
+	source := `
+extends: okabe-ito
+categories:
+  type:
+    Halogen: "#123456"
+  block:
+    s: {light: "#000000", dark: "#ffffff"}
+colors:
+  error: "#ff0000"
+`
+	theme, err := Parse("mine", []byte(source), categories)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Name != "mine" {
+		t.Errorf("Name = %q", theme.Name)
+	}
+	if got := theme.Category("type", "Halogen", ""); got != same("#123456") {
+		t.Errorf("Halogen = %v", got)
+	}
+	if got := theme.Category("type", "Nonmetal", ""); got != bluishGreen {
+		t.Errorf("Nonmetal = %v, want the okabe-ito color", got)
+	}
+	if got := theme.Category("block", "s", ""); got != (lipgloss.AdaptiveColor{Light: "#000000", Dark: "#ffffff"}) {
+		t.Errorf("s = %v", got)
+	}
+	if theme.Error != same("#ff0000") {
+		t.Errorf("Error = %v", theme.Error)
+	}
+	if OkabeIto.Categories["type"]["Halogen"] != blue {
+		t.Error("Parse changed the theme it extends")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		source       string
+		line, column int
+	}{
+		{"extends: solarized\n", 1, 10},
+		{"colours: {}\n", 1, 1},
+		{"categories:\n  group:\n    1: \"#ffffff\"\n", 2, 3},
+		{"categories:\n  type:\n    Metal: \"#ffffff\"\n", 3, 5},
+		{"colors:\n  error: red\n", 2, 10},
+		{"colors:\n  error: {light: \"#ffffff\"}\n", 2, 10},
+		{"colors:\n  border: \"#ffffff\"\n", 2, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := Parse("mine", []byte(tt.source), categories)
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("Parse() error = %v, want *Error", err)
+			}
+			if e.Line != tt.line || e.Column != tt.column {
+				t.Errorf("Parse() error at %d:%d, want %d:%d: %v", e.Line, e.Column, tt.line, tt.column, e)
+			}
+		})
+	}
+}
+
+func TestSetProfile(t *testing.T) {
+	defer SetProfile(Color)
+
+	SetProfile(Plain)
+	if !IsPlain() || Border(lipgloss.RoundedBorder()) != ASCIIBorder {
+		t.Errorf("Plain profile draws %v, want ASCII borders", Border(lipgloss.RoundedBorder()))
+	}
+
+	SetProfile(Color)
+	if IsPlain() || Border(lipgloss.RoundedBorder()) != lipgloss.RoundedBorder() {
+		t.Errorf("Color profile draws %v, want rounded borders", Border(lipgloss.RoundedBorder()))
+	}
+}
+
+func TestASCII(t *testing.T) {
+	defer SetProfile(Color)
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Density (g/cm³)", "Density (g/cm3)"},
+		{"Atomic number 26 · Transition Metal", "Atomic number 26 . Transition Metal"},
+		{"↑/k up • ↓/j down", "^/k up * v/j down"},
+		{"CuSO₄·5H₂O", "CuSO4.5H2O"},
+		{"SO₄²⁻", "SO42-"},
+		{"Ångström", "Angstr?m"},
+		{"\x1b[1mFe\x1b[0m —", "\x1b[1mFe\x1b[0m -"},
+	}
+	SetProfile(Plain)
+	for _, tt := range tests {
+		if got := ASCII(tt.text); got != tt.want {
+			t.Errorf("ASCII(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	SetProfile(Color)
+	if got := ASCII("g/cm³"); got != "g/cm³" {
+		t.Errorf("ASCII() = %q in the Color profile, want the text unchanged", got)
+	}
+}
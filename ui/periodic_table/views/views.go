@@ -0,0 +1,51 @@
+// Package views holds the messages the periodic table views send to ask the
+// top level model to switch between them. Each message carries the selected
+// element so the view being opened can keep the selection in sync.
+package views
+
+import (
+	"periodic-table/src/config"
+	"periodic-table/ui/periodic_table/element"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type OpenTableMsg struct {
+	Element element.Data
+}
+
+type OpenListMsg struct {
+	Element element.Data
+}
+
+type OpenElementMsg struct {
+	Element element.Data
+}
+
+// OpenCompareMsg opens the compare view for the pinned elements.
+type OpenCompareMsg struct {
+	Elements []element.Data
+}
+
+// OpenCalculatorMsg opens the formula calculator, returning to Element.
+// Formula, if set, is the compound built in the table, which is worked out
+// in the molar mass tab.
+type OpenCalculatorMsg struct {
+	Element element.Data
+	Formula string
+}
+
+// ConfigMsg is sent to every view when the settings are loaded or change.
+// Key remaps and display settings have already been applied when it
+// arrives, so views only need to pick up their key map and their own
+// settings.
+type ConfigMsg struct {
+	Config config.Config
+}
+
+// Open returns a command which sends msg.
+func Open(msg tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return msg
+	}
+}
@@ -1,8 +1,16 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"periodic-table/ui/periodic_table/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 var (
 	style        = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).Width(20).Height(10)
 	headingStyle = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder())
 )
+
+func errorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(theme.Current().Error)
+}
@@ -1,39 +1,183 @@
 package ui
 
 import (
+	"os"
+	"periodic-table/src/config"
 	"periodic-table/src/elements"
+	"periodic-table/ui/grid"
+	"periodic-table/ui/periodic_table/accessible"
+	"periodic-table/ui/periodic_table/calculator"
+	"periodic-table/ui/periodic_table/compare"
+	"periodic-table/ui/periodic_table/detail"
+	"periodic-table/ui/periodic_table/element"
+	"periodic-table/ui/periodic_table/list"
 	"periodic-table/ui/periodic_table/table"
+	"periodic-table/ui/periodic_table/theme"
+	"periodic-table/ui/periodic_table/views"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 const (
 	tableView = iota
 	elementView
+	listView
+	compareView
+	accessibleView
+	calculatorView
 )
 
 type Model struct {
-	table tea.Model
-	state int
+	table   tea.Model
+	element tea.Model
+	list    tea.Model
+	compare tea.Model
+	// calculator is the formula calculator.
+	calculator tea.Model
+	// accessible is only created when the accessible view is chosen, in
+	// which case it replaces every other view.
+	accessible tea.Model
+	state      int
+
+	configPath    string
+	configFiles   []string
+	configModTime time.Time
+	configErr     error
 }
 
 func (m Model) Init() tea.Cmd {
-	return nil
+	var cmds []tea.Cmd
+	if m.state == accessibleView {
+		cmds = append(cmds, m.accessible.Init())
+	}
+	if m.configPath != "" {
+		cmds = append(cmds, watchConfig(m.configPath, m.configFiles, m.configModTime))
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
-	m.table, cmd = m.table.Update(msg)
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	switch msg := msg.(type) {
+	case configMsg:
+		m.configModTime = msg.modTime
+		if msg.changed {
+			m.configErr = msg.err
+			if msg.err == nil {
+				m.applyConfig(msg.config)
+			}
+		}
+		return m, watchConfig(m.configPath, m.configFiles, m.configModTime)
+	case tea.WindowSizeMsg:
+		m.table, cmd = m.table.Update(msg)
+		cmds = append(cmds, cmd)
+		m.element, cmd = m.element.Update(msg)
+		cmds = append(cmds, cmd)
+		m.list, cmd = m.list.Update(msg)
+		cmds = append(cmds, cmd)
+		m.compare, cmd = m.compare.Update(msg)
+		cmds = append(cmds, cmd)
+		m.calculator, cmd = m.calculator.Update(msg)
+		cmds = append(cmds, cmd)
+		return m, tea.Batch(cmds...)
+	case views.OpenTableMsg:
+		m.state = tableView
+	case views.OpenElementMsg:
+		m.state = elementView
+	case views.OpenListMsg:
+		m.state = listView
+	case views.OpenCompareMsg:
+		m.state = compareView
+	case views.OpenCalculatorMsg:
+		m.state = calculatorView
+	}
+
+	switch m.state {
+	case accessibleView:
+		m.accessible, cmd = m.accessible.Update(msg)
+	case elementView:
+		m.element, cmd = m.element.Update(msg)
+	case listView:
+		m.list, cmd = m.list.Update(msg)
+	case compareView:
+		m.compare, cmd = m.compare.Update(msg)
+	case calculatorView:
+		m.calculator, cmd = m.calculator.Update(msg)
+	default:
+		m.table, cmd = m.table.Update(msg)
+	}
 	return m, cmd
 }
 
 func (m Model) View() string {
-	return m.table.View()
+	var view string
+	switch m.state {
+	case accessibleView:
+		view = m.accessible.View()
+	case elementView:
+		view = m.element.View()
+	case listView:
+		view = m.list.View()
+	case compareView:
+		view = m.compare.View()
+	case calculatorView:
+		view = m.calculator.View()
+	default:
+		view = m.table.View()
+	}
+
+	if m.configErr != nil {
+		view = lipgloss.JoinVertical(0, errorStyle().Render(m.configErr.Error()), view)
+	}
+	return theme.ASCII(view)
 }
 
-func CreateModel() (tea.Model, error) {
+// CreateModel creates the views with the settings in c. When configPath is
+// set the file is watched and the settings are reloaded when it changes.
+func CreateModel(c config.Config, configPath string) (tea.Model, error) {
 	elmts := elements.ReadElements()
 
 	t, err := table.CreateModel(elmts)
-	return Model{table: t}, err
+	if err != nil {
+		return nil, err
+	}
+	m := Model{
+		table:      t,
+		element:    detail.CreateModel(),
+		list:       list.CreateModel(elementData(elmts)),
+		compare:    compare.CreateModel(),
+		calculator: calculator.CreateModel(elementData(elmts)),
+		configPath: configPath,
+	}
+	switch c.View {
+	case "list":
+		m.state = listView
+	case "accessible":
+		m.accessible, err = accessible.CreateModel(elements.ReadElements())
+		if err != nil {
+			return nil, err
+		}
+		m.state = accessibleView
+	}
+	m.applyConfig(c)
+	if info, err := os.Stat(configPath); err == nil {
+		m.configModTime = latestModTime(info.ModTime(), m.configFiles)
+	}
+	return m, nil
+}
+
+func elementData(cells []grid.Cell) []element.Data {
+	var data []element.Data
+	for _, c := range cells {
+		if d, ok := c.GetData().(element.Data); ok && !c.IsPaddingCell() {
+			data = append(data, d)
+		}
+	}
+	return data
 }
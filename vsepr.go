@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"periodic-table/src/elements"
+	"periodic-table/src/formula"
+	"periodic-table/src/vsepr"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/exp/slices"
+)
+
+var vseprFormats = []string{"text", "json"}
+
+type vseprOptions struct {
+	format string
+}
+
+func vseprFlags() (*flag.FlagSet, *vseprOptions) {
+	var o vseprOptions
+	fs := flag.NewFlagSet("vsepr", flag.ContinueOnError)
+	fs.StringVar(&o.format, "format", "text", "output format: "+strings.Join(vseprFormats, ", "))
+	fs.Usage = commandUsage(fs, "vsepr")
+	return fs, &o
+}
+
+// runVSEPR predicts the shape of a molecule or ion around its central atom.
+// The arguments are joined by spaces as for mass.
+func runVSEPR(args []string) int {
+	fs, o := vseprFlags()
+	words, err := parseArgs(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(words) == 0 {
+		fs.Usage()
+		return 2
+	}
+	if !slices.Contains(vseprFormats, o.format) {
+		fmt.Fprintf(os.Stderr, "%s vsepr: unknown format %q, expected one of %s\n", program, o.format, strings.Join(vseprFormats, ", "))
+		return 2
+	}
+
+	f, err := formula.Parse(strings.Join(words, " "), elements.ReadData())
+	if err != nil {
+		printFormulaError(err)
+		return 2
+	}
+	m, err := vsepr.Predict(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s vsepr: %s\n", program, err)
+		return 1
+	}
+
+	if o.format == "json" {
+		err = writeVSEPRJSON(os.Stdout, m)
+	} else {
+		err = writeVSEPRText(os.Stdout, m)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s vsepr: %s\n", program, err)
+		return 1
+	}
+	return 0
+}
+
+// writeVSEPRText writes the shape of the molecule, then a sketch of it.
+func writeVSEPRText(w io.Writer, m vsepr.Molecule) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Formula\t%s\n", m.Formula.Written())
+	fmt.Fprintf(tw, "Central atom\t%s\n", m.Central.Symbol())
+	fmt.Fprintf(tw, "AXE\t%s\n", m.AXE())
+	fmt.Fprintf(tw, "Electron domains\t%d: %s\n", m.Domains(), m.Summary())
+	if m.OddElectron {
+		fmt.Fprintf(tw, "Odd electron\ton %s, counted as a lone pair\n", m.Central.Symbol())
+	}
+	fmt.Fprintf(tw, "Electron geometry\t%s\n", m.ElectronGeometry)
+	fmt.Fprintf(tw, "Molecular geometry\t%s\n", m.MolecularGeometry)
+	fmt.Fprintf(tw, "Bond angles\t%s\n", m.BondAngles)
+	fmt.Fprintf(tw, "Hybridization\t%s\n", m.Hybridization)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", m.Sketch(false))
+	return err
+}
+
+type vseprResult struct {
+	Formula           string   `json:"formula"`
+	Central           string   `json:"central"`
+	Ligands           []string `json:"ligands"`
+	LonePairs         int      `json:"lone_pairs"`
+	OddElectron       bool     `json:"odd_electron"`
+	Domains           int      `json:"domains"`
+	AXE               string   `json:"axe"`
+	ElectronGeometry  string   `json:"electron_geometry"`
+	MolecularGeometry string   `json:"molecular_geometry"`
+	BondAngles        string   `json:"bond_angles"`
+	Hybridization     string   `json:"hybridization"`
+}
+
+func writeVSEPRJSON(w io.Writer, m vsepr.Molecule) error {
+	r := vseprResult{
+		Formula:           m.Formula.Written(),
+		Central:           m.Central.Symbol(),
+		LonePairs:         m.LonePairs,
+		OddElectron:       m.OddElectron,
+		Domains:           m.Domains(),
+		AXE:               m.AXE(),
+		ElectronGeometry:  m.ElectronGeometry,
+		MolecularGeometry: m.MolecularGeometry,
+		BondAngles:        m.BondAngles,
+		Hybridization:     m.Hybridization,
+	}
+	for _, a := range m.Ligands {
+		r.Ligands = append(r.Ligands, a.Symbol())
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func completeVSEPR(args []string, word string) []string {
+	fs, _ := vseprFlags()
+	candidates, _ := completeFlag(fs, map[string]func() []string{"format": constant(vseprFormats...)}, args, word)
+	return candidates
+}